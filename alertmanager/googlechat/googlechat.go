@@ -0,0 +1,51 @@
+package googlechat
+
+import (
+	"github.com/K-Phoen/grabana/alertmanager"
+	"github.com/K-Phoen/sdk"
+)
+
+// Option represents an option that can be used to configure a "googlechat"
+// contact point type.
+type Option func(contactType *googlechatType)
+
+type googlechatType struct {
+	builder *sdk.ContactPointType
+}
+
+// Webhook creates a Google Chat contact point type that sends alerts to an
+// incoming webhook.
+// See https://developers.google.com/chat/how-tos/webhooks
+func Webhook(webhookURL string, opts ...Option) alertmanager.ContactPointOption {
+	googlechat := &googlechatType{
+		builder: &sdk.ContactPointType{
+			Type:     "googlechat",
+			Settings: map[string]interface{}{},
+			SecureSettings: map[string]interface{}{
+				"url": webhookURL,
+			},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(googlechat)
+	}
+
+	return func(contact *alertmanager.Contact) {
+		contact.Builder.GrafanaManagedReceivers = append(contact.Builder.GrafanaManagedReceivers, *googlechat.builder)
+	}
+}
+
+// Title defines a templated title that will be sent in Google Chat messages.
+func Title(templatedTitle string) Option {
+	return func(contactType *googlechatType) {
+		contactType.builder.Settings["title"] = templatedTitle
+	}
+}
+
+// Message defines the body that will be sent in Google Chat messages.
+func Message(message string) Option {
+	return func(contactType *googlechatType) {
+		contactType.builder.Settings["message"] = message
+	}
+}
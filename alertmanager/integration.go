@@ -0,0 +1,42 @@
+package alertmanager
+
+import (
+	"github.com/K-Phoen/sdk"
+)
+
+// IntegrationOption represents an option that can be used to configure any
+// contact point type. See Integration.
+type IntegrationOption func(integration *sdk.ContactPointType)
+
+// Integration applies options common to every contact point type to the
+// ones defined by the given option.
+// Example: Integration(slack.Webhook(url), DisableResolveMessage())
+func Integration(integration ContactPointOption, opts ...IntegrationOption) ContactPointOption {
+	return func(contactPoint *Contact) {
+		first := len(contactPoint.Builder.GrafanaManagedReceivers)
+
+		integration(contactPoint)
+
+		for i := first; i < len(contactPoint.Builder.GrafanaManagedReceivers); i++ {
+			for _, opt := range opts {
+				opt(&contactPoint.Builder.GrafanaManagedReceivers[i])
+			}
+		}
+	}
+}
+
+// DisableResolveMessage disables the notification sent when alerts go back
+// to normal.
+func DisableResolveMessage() IntegrationOption {
+	return func(integration *sdk.ContactPointType) {
+		integration.DisableResolveMessage = true
+	}
+}
+
+// IntegrationUID sets the UID of the contact point type, so that it keeps its
+// identity across provisioning runs.
+func IntegrationUID(uid string) IntegrationOption {
+	return func(integration *sdk.ContactPointType) {
+		integration.UID = uid
+	}
+}
@@ -0,0 +1,47 @@
+package alertmanager
+
+import (
+	"testing"
+
+	"github.com/K-Phoen/sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisableResolveMessage(t *testing.T) {
+	req := require.New(t)
+
+	integration := &sdk.ContactPointType{}
+	DisableResolveMessage()(integration)
+
+	req.True(integration.DisableResolveMessage)
+}
+
+func TestIntegrationUID(t *testing.T) {
+	req := require.New(t)
+
+	integration := &sdk.ContactPointType{}
+	IntegrationUID("team-a-slack")(integration)
+
+	req.Equal("team-a-slack", integration.UID)
+}
+
+func TestIntegrationAppliesCommonOptionsToTheGivenIntegrations(t *testing.T) {
+	req := require.New(t)
+
+	integration := func(contactPoint *Contact) {
+		contactPoint.Builder.GrafanaManagedReceivers = append(contactPoint.Builder.GrafanaManagedReceivers, sdk.ContactPointType{Type: "slack"})
+	}
+
+	contactPoint := ContactPoint(
+		"team-a",
+		integration,
+		Integration(integration, DisableResolveMessage(), IntegrationUID("team-a-slack")),
+	)
+
+	receivers := contactPoint.Builder.GrafanaManagedReceivers
+	req.Len(receivers, 2)
+	req.False(receivers[0].DisableResolveMessage)
+	req.Empty(receivers[0].UID)
+	req.True(receivers[1].DisableResolveMessage)
+	req.Equal("team-a-slack", receivers[1].UID)
+}
@@ -12,13 +12,43 @@ type Option func(manager *Manager)
 
 // Manager represents an alert manager.
 type Manager struct {
-	builder *sdk.AlertManager
+	builder *alertManagerModel
+}
+
+// alertManagerModel mirrors sdk.AlertManager, with the parts of the
+// configuration the sdk doesn't know about (mute timings, …).
+type alertManagerModel struct {
+	Config        alertManagerConfig  `json:"alertmanager_config"`
+	TemplateFiles sdk.MessageTemplate `json:"template_files"`
+}
+
+type alertManagerConfig struct {
+	Receivers         []sdk.ContactPoint   `json:"receivers"`
+	Route             notificationPolicies `json:"route"`
+	Templates         []string             `json:"templates"`
+	MuteTimeIntervals []timeIntervalModel  `json:"mute_time_intervals,omitempty"`
+}
+
+type notificationPolicies struct {
+	// Default alert receiver
+	Receiver string `json:"receiver"`
+
+	// Default group bys
+	GroupBy []string `json:"group_by,omitempty"`
+
+	// Default timing settings
+	GroupInterval  string `json:"group_interval,omitempty"`
+	GroupWait      string `json:"group_wait,omitempty"`
+	RepeatInterval string `json:"repeat_interval,omitempty"`
+
+	// Routing policies
+	Routes []routingPolicyModel `json:"routes,omitempty"`
 }
 
 // New creates a new alert manager.
 func New(opts ...Option) *Manager {
 	manager := &Manager{
-		builder: &sdk.AlertManager{},
+		builder: &alertManagerModel{},
 	}
 
 	for _, opt := range opts {
@@ -61,6 +91,30 @@ func DefaultGroupBys(labels ...string) Option {
 	}
 }
 
+// DefaultGroupWait sets how long to initially wait before sending a
+// notification for a new group of alerts. Example: "30s".
+func DefaultGroupWait(wait string) Option {
+	return func(manager *Manager) {
+		manager.builder.Config.Route.GroupWait = wait
+	}
+}
+
+// DefaultGroupInterval sets how long to wait before sending a notification
+// about new alerts added to a group. Example: "5m".
+func DefaultGroupInterval(interval string) Option {
+	return func(manager *Manager) {
+		manager.builder.Config.Route.GroupInterval = interval
+	}
+}
+
+// DefaultRepeatInterval sets how long to wait before sending a notification
+// again when alerts are still firing. Example: "4h".
+func DefaultRepeatInterval(interval string) Option {
+	return func(manager *Manager) {
+		manager.builder.Config.Route.RepeatInterval = interval
+	}
+}
+
 // Templates defines templates that can be used when sending messages to
 // contact points.
 // See https://prometheus.io/blog/2016/03/03/custom-alertmanager-templates/
@@ -70,6 +124,18 @@ func Templates(templates map[string]string) Option {
 	}
 }
 
+// TimeIntervals defines the mute timings that routing policies can refer
+// to, with MuteTimings() or ActiveTimings().
+func TimeIntervals(intervals ...TimeInterval) Option {
+	return func(manager *Manager) {
+		manager.builder.Config.MuteTimeIntervals = nil
+
+		for _, interval := range intervals {
+			manager.builder.Config.MuteTimeIntervals = append(manager.builder.Config.MuteTimeIntervals, *interval.builder)
+		}
+	}
+}
+
 // Routing configures the routing policies to apply on alerts.
 func Routing(policies ...RoutingPolicy) Option {
 	return func(manager *Manager) {
@@ -83,11 +149,21 @@ func Routing(policies ...RoutingPolicy) Option {
 }
 
 // MarshalJSON implements the encoding/json.Marshaler interface.
+// The routing tree is validated first, see Validate().
 func (manager *Manager) MarshalJSON() ([]byte, error) {
+	if err := manager.Validate(); err != nil {
+		return nil, err
+	}
+
 	return json.Marshal(manager.builder)
 }
 
 // MarshalIndentJSON renders the manager as indented JSON.
+// The routing tree is validated first, see Validate().
 func (manager *Manager) MarshalIndentJSON() ([]byte, error) {
+	if err := manager.Validate(); err != nil {
+		return nil, err
+	}
+
 	return json.MarshalIndent(manager.builder, "", "  ")
 }
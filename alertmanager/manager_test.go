@@ -31,6 +31,20 @@ func TestDefaultGroupBy(t *testing.T) {
 	req.ElementsMatch([]string{"priority", "service"}, manager.builder.Config.Route.GroupBy)
 }
 
+func TestDefaultTimings(t *testing.T) {
+	req := require.New(t)
+
+	manager := New(
+		DefaultGroupWait("30s"),
+		DefaultGroupInterval("5m"),
+		DefaultRepeatInterval("4h"),
+	)
+
+	req.Equal("30s", manager.builder.Config.Route.GroupWait)
+	req.Equal("5m", manager.builder.Config.Route.GroupInterval)
+	req.Equal("4h", manager.builder.Config.Route.RepeatInterval)
+}
+
 func TestDefaultContactPointCanBeImplicit(t *testing.T) {
 	req := require.New(t)
 
@@ -86,6 +100,23 @@ func TestTemplates(t *testing.T) {
 	}, manager.builder.TemplateFiles)
 }
 
+func TestTimeIntervals(t *testing.T) {
+	req := require.New(t)
+
+	manager := New(
+		TimeIntervals(
+			MuteTiming("off-hours", Period(Weekdays("saturday", "sunday"))),
+		),
+		Routing(
+			Policy("team-a", MuteTimings("off-hours")),
+		),
+	)
+
+	req.Len(manager.builder.Config.MuteTimeIntervals, 1)
+	req.Equal("off-hours", manager.builder.Config.MuteTimeIntervals[0].Name)
+	req.Equal([]string{"off-hours"}, manager.builder.Config.Route.Routes[0].MuteTimeIntervals)
+}
+
 func TestMarshalJSON(t *testing.T) {
 	req := require.New(t)
 
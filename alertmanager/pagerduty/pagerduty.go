@@ -0,0 +1,84 @@
+package pagerduty
+
+import (
+	"github.com/K-Phoen/grabana/alertmanager"
+	"github.com/K-Phoen/sdk"
+)
+
+// Option represents an option that can be used to configure a "pagerduty"
+// contact point type.
+type Option func(contactType *pagerdutyType)
+
+// Severity represents the severity of the events sent to PagerDuty.
+type Severity string
+
+const (
+	Critical Severity = "critical"
+	Error    Severity = "error"
+	Warning  Severity = "warning"
+	Info     Severity = "info"
+)
+
+type pagerdutyType struct {
+	builder *sdk.ContactPointType
+}
+
+// With creates a PagerDuty contact point type that sends events to the
+// service identified by the given integration key.
+// See https://support.pagerduty.com/docs/services-and-integrations
+func With(integrationKey string, opts ...Option) alertmanager.ContactPointOption {
+	pagerduty := &pagerdutyType{
+		builder: &sdk.ContactPointType{
+			Type:     "pagerduty",
+			Settings: map[string]interface{}{},
+			SecureSettings: map[string]interface{}{
+				"integrationKey": integrationKey,
+			},
+		},
+	}
+
+	defaultOpts := []Option{WithSeverity(Critical)}
+	for _, opt := range append(defaultOpts, opts...) {
+		opt(pagerduty)
+	}
+
+	return func(contact *alertmanager.Contact) {
+		contact.Builder.GrafanaManagedReceivers = append(contact.Builder.GrafanaManagedReceivers, *pagerduty.builder)
+	}
+}
+
+// WithSeverity sets the severity of the events. Defaults to Critical.
+func WithSeverity(severity Severity) Option {
+	return func(contactType *pagerdutyType) {
+		contactType.builder.Settings["severity"] = string(severity)
+	}
+}
+
+// Class sets the class or type of the events. Example: "ping failure".
+func Class(class string) Option {
+	return func(contactType *pagerdutyType) {
+		contactType.builder.Settings["class"] = class
+	}
+}
+
+// Component sets the component of the source machine responsible for the
+// events. Example: "mysql".
+func Component(component string) Option {
+	return func(contactType *pagerdutyType) {
+		contactType.builder.Settings["component"] = component
+	}
+}
+
+// Group sets the logical group of components of a service. Example: "app-stack".
+func Group(group string) Option {
+	return func(contactType *pagerdutyType) {
+		contactType.builder.Settings["group"] = group
+	}
+}
+
+// Summary defines a templated summary of the events.
+func Summary(summary string) Option {
+	return func(contactType *pagerdutyType) {
+		contactType.builder.Settings["summary"] = summary
+	}
+}
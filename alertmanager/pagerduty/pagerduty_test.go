@@ -0,0 +1,77 @@
+package pagerduty
+
+import (
+	"testing"
+
+	"github.com/K-Phoen/grabana/alertmanager"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWith(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", With("integration-key"))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("pagerduty", contactType.Type)
+	req.Equal("integration-key", contactType.SecureSettings["integrationKey"].(string))
+	req.Equal("critical", contactType.Settings["severity"].(string))
+	req.NotContains(contactType.Settings, "integrationKey")
+}
+
+func TestWithSeverity(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", With("", WithSeverity(Warning)))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("warning", contactType.Settings["severity"])
+}
+
+func TestClass(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", With("", Class("ping failure")))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("ping failure", contactType.Settings["class"])
+}
+
+func TestComponent(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", With("", Component("mysql")))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("mysql", contactType.Settings["component"])
+}
+
+func TestGroup(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", With("", Group("app-stack")))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("app-stack", contactType.Settings["group"])
+}
+
+func TestSummary(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", With("", Summary("{{ .CommonLabels.alertname }}")))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("{{ .CommonLabels.alertname }}", contactType.Settings["summary"])
+}
@@ -0,0 +1,121 @@
+package pushover
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/K-Phoen/grabana/alertmanager"
+	"github.com/K-Phoen/sdk"
+)
+
+// Option represents an option that can be used to configure a "pushover"
+// contact point type.
+type Option func(contactType *pushoverType)
+
+// Priority represents the priority of Pushover notifications.
+// See https://pushover.net/api#priority
+type Priority int
+
+const (
+	Lowest    Priority = -2
+	Low       Priority = -1
+	Normal    Priority = 0
+	High      Priority = 1
+	Emergency Priority = 2
+)
+
+type pushoverType struct {
+	builder *sdk.ContactPointType
+}
+
+// With creates a Pushover contact point type that notifies the given user
+// or group, through the application identified by the given API token.
+// See https://pushover.net/api
+func With(userKey string, apiToken string, opts ...Option) alertmanager.ContactPointOption {
+	pushover := &pushoverType{
+		builder: &sdk.ContactPointType{
+			Type:     "pushover",
+			Settings: map[string]interface{}{},
+			SecureSettings: map[string]interface{}{
+				"userKey":  userKey,
+				"apiToken": apiToken,
+			},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(pushover)
+	}
+
+	return func(contact *alertmanager.Contact) {
+		contact.Builder.GrafanaManagedReceivers = append(contact.Builder.GrafanaManagedReceivers, *pushover.builder)
+	}
+}
+
+// AlertingPriority sets the priority of the notifications sent for firing
+// alerts.
+func AlertingPriority(priority Priority) Option {
+	return func(contactType *pushoverType) {
+		contactType.builder.Settings["priority"] = strconv.Itoa(int(priority))
+	}
+}
+
+// OKPriority sets the priority of the notifications sent for resolved alerts.
+func OKPriority(priority Priority) Option {
+	return func(contactType *pushoverType) {
+		contactType.builder.Settings["okPriority"] = strconv.Itoa(int(priority))
+	}
+}
+
+// Retry sets how often, in seconds, Pushover sends emergency notifications
+// again until they are acknowledged. Must be at least 30.
+func Retry(seconds int) Option {
+	return func(contactType *pushoverType) {
+		contactType.builder.Settings["retry"] = strconv.Itoa(seconds)
+	}
+}
+
+// Expire sets for how long, in seconds, Pushover keeps retrying emergency
+// notifications.
+func Expire(seconds int) Option {
+	return func(contactType *pushoverType) {
+		contactType.builder.Settings["expire"] = strconv.Itoa(seconds)
+	}
+}
+
+// Devices restricts the notifications to the given devices of the user.
+func Devices(devices ...string) Option {
+	return func(contactType *pushoverType) {
+		contactType.builder.Settings["device"] = strings.Join(devices, ",")
+	}
+}
+
+// AlertingSound sets the sound played for firing alerts.
+// See https://pushover.net/api#sounds
+func AlertingSound(sound string) Option {
+	return func(contactType *pushoverType) {
+		contactType.builder.Settings["sound"] = sound
+	}
+}
+
+// OKSound sets the sound played for resolved alerts.
+// See https://pushover.net/api#sounds
+func OKSound(sound string) Option {
+	return func(contactType *pushoverType) {
+		contactType.builder.Settings["okSound"] = sound
+	}
+}
+
+// Title defines a templated title that will be sent in Pushover notifications.
+func Title(templatedTitle string) Option {
+	return func(contactType *pushoverType) {
+		contactType.builder.Settings["title"] = templatedTitle
+	}
+}
+
+// Message defines the body that will be sent in Pushover notifications.
+func Message(message string) Option {
+	return func(contactType *pushoverType) {
+		contactType.builder.Settings["message"] = message
+	}
+}
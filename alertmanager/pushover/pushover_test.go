@@ -0,0 +1,120 @@
+package pushover
+
+import (
+	"testing"
+
+	"github.com/K-Phoen/grabana/alertmanager"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWith(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", With("user-key", "api-token"))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("pushover", contactType.Type)
+	req.Equal("user-key", contactType.SecureSettings["userKey"].(string))
+	req.Equal("api-token", contactType.SecureSettings["apiToken"].(string))
+}
+
+func TestAlertingPriority(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", With("", "", AlertingPriority(Emergency)))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("2", contactType.Settings["priority"])
+}
+
+func TestOKPriority(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", With("", "", OKPriority(Lowest)))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("-2", contactType.Settings["okPriority"])
+}
+
+func TestRetry(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", With("", "", Retry(60)))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("60", contactType.Settings["retry"])
+}
+
+func TestExpire(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", With("", "", Expire(3600)))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("3600", contactType.Settings["expire"])
+}
+
+func TestDevices(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", With("", "", Devices("phone", "tablet")))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("phone,tablet", contactType.Settings["device"])
+}
+
+func TestAlertingSound(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", With("", "", AlertingSound("siren")))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("siren", contactType.Settings["sound"])
+}
+
+func TestOKSound(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", With("", "", OKSound("magic")))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("magic", contactType.Settings["okSound"])
+}
+
+func TestTitle(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", With("", "", Title("title")))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("title", contactType.Settings["title"])
+}
+
+func TestMessage(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", With("", "", Message("message")))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("message", contactType.Settings["message"])
+}
@@ -10,14 +10,40 @@ type RoutingPolicyOption func(policy *RoutingPolicy)
 
 // RoutingPolicy represents a routing policy.
 type RoutingPolicy struct {
-	builder *sdk.NotificationRoutingPolicy
+	builder *routingPolicyModel
+}
+
+// routingPolicyModel mirrors sdk.NotificationRoutingPolicy, with the fields
+// the sdk doesn't know about.
+type routingPolicyModel struct {
+	// Alert receiver
+	Receiver string `json:"receiver"`
+	// Group bys and timing settings overrides
+	GroupBy        []string `json:"group_by,omitempty"`
+	GroupInterval  string   `json:"group_interval,omitempty"`
+	GroupWait      string   `json:"group_wait,omitempty"`
+	RepeatInterval string   `json:"repeat_interval,omitempty"`
+
+	ObjectMatchers []sdk.AlertObjectMatcher `json:"object_matchers"`
+
+	// Names of the time intervals during which the policy is muted, or
+	// outside of which it is muted.
+	MuteTimeIntervals   []string `json:"mute_time_intervals,omitempty"`
+	ActiveTimeIntervals []string `json:"active_time_intervals,omitempty"`
+
+	// Continue matching the sibling policies once this one matched
+	Continue bool `json:"continue,omitempty"`
+	// Nested policies
+	Routes []routingPolicyModel `json:"routes,omitempty"`
 }
 
 // Policy defines a routing policy that applies to the given contact point.
 // All the options given on this policy will be combined using a logical "AND".
+// Nested policies can leave the contact point empty to inherit their
+// parent's.
 func Policy(contactPoint string, opts ...RoutingPolicyOption) RoutingPolicy {
 	policy := &RoutingPolicy{
-		builder: &sdk.NotificationRoutingPolicy{
+		builder: &routingPolicyModel{
 			Receiver:       contactPoint,
 			ObjectMatchers: nil,
 		},
@@ -65,3 +91,68 @@ func TagNotMatches(tag string, regex string) RoutingPolicyOption {
 		})
 	}
 }
+
+// MuteTimings mutes the policy during the given time intervals, referenced
+// by name. See TimeIntervals() and MuteTiming().
+func MuteTimings(names ...string) RoutingPolicyOption {
+	return func(policy *RoutingPolicy) {
+		policy.builder.MuteTimeIntervals = append(policy.builder.MuteTimeIntervals, names...)
+	}
+}
+
+// ActiveTimings mutes the policy outside of the given time intervals,
+// referenced by name. See TimeIntervals() and MuteTiming().
+func ActiveTimings(names ...string) RoutingPolicyOption {
+	return func(policy *RoutingPolicy) {
+		policy.builder.ActiveTimeIntervals = append(policy.builder.ActiveTimeIntervals, names...)
+	}
+}
+
+// Nested defines policies that are evaluated against the alerts matching
+// this one. Alerts matching none of them are handled by this policy.
+func Nested(policies ...RoutingPolicy) RoutingPolicyOption {
+	return func(policy *RoutingPolicy) {
+		for _, nested := range policies {
+			policy.builder.Routes = append(policy.builder.Routes, *nested.builder)
+		}
+	}
+}
+
+// Continue keeps on evaluating the sibling policies once this one matched.
+// By default, the first matching policy stops the evaluation.
+func Continue() RoutingPolicyOption {
+	return func(policy *RoutingPolicy) {
+		policy.builder.Continue = true
+	}
+}
+
+// GroupBy overrides the labels that alerts should be grouped by.
+func GroupBy(labels ...string) RoutingPolicyOption {
+	return func(policy *RoutingPolicy) {
+		policy.builder.GroupBy = labels
+	}
+}
+
+// GroupWait overrides how long to initially wait before sending a
+// notification for a new group of alerts. Example: "30s".
+func GroupWait(wait string) RoutingPolicyOption {
+	return func(policy *RoutingPolicy) {
+		policy.builder.GroupWait = wait
+	}
+}
+
+// GroupInterval overrides how long to wait before sending a notification
+// about new alerts added to a group. Example: "5m".
+func GroupInterval(interval string) RoutingPolicyOption {
+	return func(policy *RoutingPolicy) {
+		policy.builder.GroupInterval = interval
+	}
+}
+
+// RepeatInterval overrides how long to wait before sending a notification
+// again when alerts are still firing. Example: "4h".
+func RepeatInterval(interval string) RoutingPolicyOption {
+	return func(policy *RoutingPolicy) {
+		policy.builder.RepeatInterval = interval
+	}
+}
@@ -66,3 +66,62 @@ func TestTagNotMatches(t *testing.T) {
 	req.Equal("!~", matcher[1])
 	req.Equal("P[345]", matcher[2])
 }
+
+func TestMuteTimings(t *testing.T) {
+	req := require.New(t)
+
+	policy := Policy("team-a", MuteTimings("off-hours", "maintenance"))
+
+	req.Equal([]string{"off-hours", "maintenance"}, policy.builder.MuteTimeIntervals)
+}
+
+func TestActiveTimings(t *testing.T) {
+	req := require.New(t)
+
+	policy := Policy("team-a", ActiveTimings("business-hours"))
+
+	req.Equal([]string{"business-hours"}, policy.builder.ActiveTimeIntervals)
+}
+
+func TestNested(t *testing.T) {
+	req := require.New(t)
+
+	policy := Policy("team-a",
+		TagEq("owner", "team-a"),
+		Nested(
+			Policy("team-a-pager", TagEq("severity", "critical")),
+			Policy("", TagEq("severity", "info"), GroupBy("service")),
+		),
+	)
+
+	req.Len(policy.builder.Routes, 2)
+	req.Equal("team-a-pager", policy.builder.Routes[0].Receiver)
+	req.Empty(policy.builder.Routes[1].Receiver)
+	req.Equal([]string{"service"}, policy.builder.Routes[1].GroupBy)
+}
+
+func TestContinue(t *testing.T) {
+	req := require.New(t)
+
+	policy := Policy("team-a", Continue())
+
+	req.True(policy.builder.Continue)
+}
+
+func TestGroupBy(t *testing.T) {
+	req := require.New(t)
+
+	policy := Policy("team-a", GroupBy("alertname", "service"))
+
+	req.Equal([]string{"alertname", "service"}, policy.builder.GroupBy)
+}
+
+func TestTimingOverrides(t *testing.T) {
+	req := require.New(t)
+
+	policy := Policy("team-a", GroupWait("10s"), GroupInterval("1m"), RepeatInterval("1h"))
+
+	req.Equal("10s", policy.builder.GroupWait)
+	req.Equal("1m", policy.builder.GroupInterval)
+	req.Equal("1h", policy.builder.RepeatInterval)
+}
@@ -0,0 +1,86 @@
+package sns
+
+import (
+	"github.com/K-Phoen/grabana/alertmanager"
+	"github.com/K-Phoen/sdk"
+)
+
+// Option represents an option that can be used to configure a "sns"
+// contact point type.
+type Option func(contactType *snsType)
+
+type snsType struct {
+	builder *sdk.ContactPointType
+}
+
+// Topic creates an Amazon SNS contact point type that publishes alerts to
+// the given topic. Unless Credentials() or Profile() are given, the
+// credentials available to Grafana are used.
+// See https://docs.aws.amazon.com/sns/latest/dg/welcome.html
+func Topic(topicARN string, opts ...Option) alertmanager.ContactPointOption {
+	sns := &snsType{
+		builder: &sdk.ContactPointType{
+			Type: "sns",
+			Settings: map[string]interface{}{
+				"topic_arn": topicARN,
+				"sigv4":     map[string]interface{}{},
+			},
+			SecureSettings: map[string]interface{}{},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(sns)
+	}
+
+	return func(contact *alertmanager.Contact) {
+		contact.Builder.GrafanaManagedReceivers = append(contact.Builder.GrafanaManagedReceivers, *sns.builder)
+	}
+}
+
+func (contactType *snsType) sigv4() map[string]interface{} {
+	return contactType.builder.Settings["sigv4"].(map[string]interface{})
+}
+
+// Region sets the AWS region of the topic. Example: "eu-west-1".
+func Region(region string) Option {
+	return func(contactType *snsType) {
+		contactType.sigv4()["region"] = region
+	}
+}
+
+// Credentials sets the AWS access key used to publish messages.
+func Credentials(accessKey string, secretKey string) Option {
+	return func(contactType *snsType) {
+		contactType.builder.SecureSettings["sigv4.access_key"] = accessKey
+		contactType.builder.SecureSettings["sigv4.secret_key"] = secretKey
+	}
+}
+
+// Profile sets the named AWS profile used to publish messages.
+func Profile(profile string) Option {
+	return func(contactType *snsType) {
+		contactType.sigv4()["profile"] = profile
+	}
+}
+
+// AssumeRole sets the ARN of a role to assume to publish messages.
+func AssumeRole(roleARN string) Option {
+	return func(contactType *snsType) {
+		contactType.sigv4()["role_arn"] = roleARN
+	}
+}
+
+// Subject defines a templated subject for the messages.
+func Subject(subject string) Option {
+	return func(contactType *snsType) {
+		contactType.builder.Settings["subject"] = subject
+	}
+}
+
+// Message defines the templated body of the messages.
+func Message(message string) Option {
+	return func(contactType *snsType) {
+		contactType.builder.Settings["message"] = message
+	}
+}
@@ -0,0 +1,64 @@
+package sns
+
+import (
+	"testing"
+
+	"github.com/K-Phoen/grabana/alertmanager"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopic(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", Topic("topic-arn"))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("sns", contactType.Type)
+	req.Equal("topic-arn", contactType.Settings["topic_arn"].(string))
+}
+
+func TestAuthentication(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", Topic("",
+		Region("eu-west-1"),
+		Profile("alerting"),
+		AssumeRole("role-arn"),
+		Credentials("access-key", "secret-key"),
+	))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal(map[string]interface{}{
+		"region":   "eu-west-1",
+		"profile":  "alerting",
+		"role_arn": "role-arn",
+	}, contactType.Settings["sigv4"])
+	req.Equal("access-key", contactType.SecureSettings["sigv4.access_key"].(string))
+	req.Equal("secret-key", contactType.SecureSettings["sigv4.secret_key"].(string))
+}
+
+func TestSubject(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", Topic("", Subject("subject")))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("subject", contactType.Settings["subject"])
+}
+
+func TestMessage(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", Topic("", Message("message")))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("message", contactType.Settings["message"])
+}
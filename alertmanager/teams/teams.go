@@ -0,0 +1,58 @@
+package teams
+
+import (
+	"github.com/K-Phoen/grabana/alertmanager"
+	"github.com/K-Phoen/sdk"
+)
+
+// Option represents an option that can be used to configure a "teams"
+// contact point type.
+type Option func(contactType *teamsType)
+
+type teamsType struct {
+	builder *sdk.ContactPointType
+}
+
+// Webhook creates a Microsoft Teams contact point type that sends alerts to
+// an incoming webhook.
+// See https://learn.microsoft.com/en-us/microsoftteams/platform/webhooks-and-connectors/how-to/add-incoming-webhook
+func Webhook(webhookURL string, opts ...Option) alertmanager.ContactPointOption {
+	teams := &teamsType{
+		builder: &sdk.ContactPointType{
+			Type:     "teams",
+			Settings: map[string]interface{}{},
+			SecureSettings: map[string]interface{}{
+				"url": webhookURL,
+			},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(teams)
+	}
+
+	return func(contact *alertmanager.Contact) {
+		contact.Builder.GrafanaManagedReceivers = append(contact.Builder.GrafanaManagedReceivers, *teams.builder)
+	}
+}
+
+// Title defines a templated title that will be sent in Teams messages.
+func Title(templatedTitle string) Option {
+	return func(contactType *teamsType) {
+		contactType.builder.Settings["title"] = templatedTitle
+	}
+}
+
+// SectionTitle defines a templated title for the section of Teams messages.
+func SectionTitle(templatedTitle string) Option {
+	return func(contactType *teamsType) {
+		contactType.builder.Settings["sectiontitle"] = templatedTitle
+	}
+}
+
+// Message defines the body that will be sent in Teams messages.
+func Message(message string) Option {
+	return func(contactType *teamsType) {
+		contactType.builder.Settings["message"] = message
+	}
+}
@@ -0,0 +1,53 @@
+package teams
+
+import (
+	"testing"
+
+	"github.com/K-Phoen/grabana/alertmanager"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", Webhook("webhook-url"))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("teams", contactType.Type)
+	req.Equal("webhook-url", contactType.SecureSettings["url"].(string))
+}
+
+func TestTitle(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", Webhook("", Title("title")))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("title", contactType.Settings["title"])
+}
+
+func TestSectionTitle(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", Webhook("", SectionTitle("section")))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("section", contactType.Settings["sectiontitle"])
+}
+
+func TestMessage(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", Webhook("", Message("message")))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("message", contactType.Settings["message"])
+}
@@ -0,0 +1,86 @@
+package telegram
+
+import (
+	"github.com/K-Phoen/grabana/alertmanager"
+	"github.com/K-Phoen/sdk"
+)
+
+// Option represents an option that can be used to configure a "telegram"
+// contact point type.
+type Option func(contactType *telegramType)
+
+// ParseMode describes how Telegram formats messages.
+type ParseMode string
+
+const (
+	Markdown   ParseMode = "Markdown"
+	MarkdownV2 ParseMode = "MarkdownV2"
+	HTML       ParseMode = "HTML"
+	// None sends messages as plain text.
+	None ParseMode = "None"
+)
+
+type telegramType struct {
+	builder *sdk.ContactPointType
+}
+
+// Bot creates a Telegram contact point type that sends alerts to the given
+// chat, as the bot identified by the given token.
+// See https://core.telegram.org/bots
+func Bot(botToken string, chatID string, opts ...Option) alertmanager.ContactPointOption {
+	telegram := &telegramType{
+		builder: &sdk.ContactPointType{
+			Type: "telegram",
+			Settings: map[string]interface{}{
+				"chatid": chatID,
+			},
+			SecureSettings: map[string]interface{}{
+				"bottoken": botToken,
+			},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(telegram)
+	}
+
+	return func(contact *alertmanager.Contact) {
+		contact.Builder.GrafanaManagedReceivers = append(contact.Builder.GrafanaManagedReceivers, *telegram.builder)
+	}
+}
+
+// Message defines the templated message that will be sent.
+func Message(message string) Option {
+	return func(contactType *telegramType) {
+		contactType.builder.Settings["message"] = message
+	}
+}
+
+// WithParseMode sets how Telegram formats the messages.
+func WithParseMode(mode ParseMode) Option {
+	return func(contactType *telegramType) {
+		contactType.builder.Settings["parse_mode"] = string(mode)
+	}
+}
+
+// DisableNotification sends the messages silently: users receive them
+// without sound.
+func DisableNotification() Option {
+	return func(contactType *telegramType) {
+		contactType.builder.Settings["disable_notification"] = true
+	}
+}
+
+// DisableWebPagePreview disables link previews for links in the messages.
+func DisableWebPagePreview() Option {
+	return func(contactType *telegramType) {
+		contactType.builder.Settings["disable_web_page_preview"] = true
+	}
+}
+
+// ProtectContent protects the messages from forwarding and saving.
+func ProtectContent() Option {
+	return func(contactType *telegramType) {
+		contactType.builder.Settings["protect_content"] = true
+	}
+}
@@ -0,0 +1,76 @@
+package telegram
+
+import (
+	"testing"
+
+	"github.com/K-Phoen/grabana/alertmanager"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBot(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", Bot("bot-token", "chat-id"))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("telegram", contactType.Type)
+	req.Equal("bot-token", contactType.SecureSettings["bottoken"].(string))
+	req.Equal("chat-id", contactType.Settings["chatid"].(string))
+}
+
+func TestMessage(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", Bot("", "", Message("message")))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("message", contactType.Settings["message"])
+}
+
+func TestWithParseMode(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", Bot("", "", WithParseMode(MarkdownV2)))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal("MarkdownV2", contactType.Settings["parse_mode"])
+}
+
+func TestDisableNotification(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", Bot("", "", DisableNotification()))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal(true, contactType.Settings["disable_notification"])
+}
+
+func TestDisableWebPagePreview(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", Bot("", "", DisableWebPagePreview()))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal(true, contactType.Settings["disable_web_page_preview"])
+}
+
+func TestProtectContent(t *testing.T) {
+	req := require.New(t)
+
+	contactPoint := alertmanager.ContactPoint("", Bot("", "", ProtectContent()))
+
+	req.Len(contactPoint.Builder.GrafanaManagedReceivers, 1)
+
+	contactType := contactPoint.Builder.GrafanaManagedReceivers[0]
+	req.Equal(true, contactType.Settings["protect_content"])
+}
@@ -0,0 +1,131 @@
+package alertmanager
+
+import (
+	"encoding/json"
+)
+
+// TimeIntervalOption represents an option that can be used to configure a
+// mute timing.
+type TimeIntervalOption func(interval *TimeInterval)
+
+// PeriodOption represents an option that can be used to configure a period
+// of a mute timing.
+type PeriodOption func(period *periodModel)
+
+// TimeInterval represents a named set of periods, known as "mute timing"
+// in Grafana. Routing policies refer to it to be muted during these periods
+// (or outside of them).
+// See https://grafana.com/docs/grafana/latest/alerting/manage-notifications/mute-timings/
+type TimeInterval struct {
+	builder *timeIntervalModel
+}
+
+type timeIntervalModel struct {
+	Name          string        `json:"name"`
+	TimeIntervals []periodModel `json:"time_intervals"`
+}
+
+type periodModel struct {
+	Times       []timeRange `json:"times,omitempty"`
+	Weekdays    []string    `json:"weekdays,omitempty"`
+	DaysOfMonth []string    `json:"days_of_month,omitempty"`
+	Months      []string    `json:"months,omitempty"`
+	Years       []string    `json:"years,omitempty"`
+	Location    string      `json:"location,omitempty"`
+}
+
+type timeRange struct {
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+// MuteTiming defines a new mute timing. It matches when any of its periods
+// matches.
+func MuteTiming(name string, opts ...TimeIntervalOption) TimeInterval {
+	interval := &TimeInterval{
+		builder: &timeIntervalModel{
+			Name:          name,
+			TimeIntervals: []periodModel{},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(interval)
+	}
+
+	return *interval
+}
+
+// Name returns the name of the mute timing.
+func (interval TimeInterval) Name() string {
+	return interval.builder.Name
+}
+
+// MarshalJSON implements the encoding/json.Marshaler interface.
+func (interval TimeInterval) MarshalJSON() ([]byte, error) {
+	return json.Marshal(interval.builder)
+}
+
+// Period adds a period to the mute timing. All the options given on this
+// period are combined using a logical "AND".
+func Period(opts ...PeriodOption) TimeIntervalOption {
+	return func(interval *TimeInterval) {
+		period := &periodModel{}
+
+		for _, opt := range opts {
+			opt(period)
+		}
+
+		interval.builder.TimeIntervals = append(interval.builder.TimeIntervals, *period)
+	}
+}
+
+// Times restricts the period to the given time range, in the "HH:MM"
+// format. The end of the range is excluded. Can be given several times.
+// Example: Times("18:00", "24:00").
+func Times(start string, end string) PeriodOption {
+	return func(period *periodModel) {
+		period.Times = append(period.Times, timeRange{StartTime: start, EndTime: end})
+	}
+}
+
+// Weekdays restricts the period to the given days of the week, or ranges
+// of days. Example: Weekdays("monday:friday", "sunday").
+func Weekdays(days ...string) PeriodOption {
+	return func(period *periodModel) {
+		period.Weekdays = append(period.Weekdays, days...)
+	}
+}
+
+// DaysOfMonth restricts the period to the given days of the month, or
+// ranges of days. Negative values count from the end of the month.
+// Example: DaysOfMonth("1:5", "-1").
+func DaysOfMonth(days ...string) PeriodOption {
+	return func(period *periodModel) {
+		period.DaysOfMonth = append(period.DaysOfMonth, days...)
+	}
+}
+
+// Months restricts the period to the given months, or ranges of months.
+// Example: Months("january:march", "12").
+func Months(months ...string) PeriodOption {
+	return func(period *periodModel) {
+		period.Months = append(period.Months, months...)
+	}
+}
+
+// Years restricts the period to the given years, or ranges of years.
+// Example: Years("2024:2025").
+func Years(years ...string) PeriodOption {
+	return func(period *periodModel) {
+		period.Years = append(period.Years, years...)
+	}
+}
+
+// Location sets the time zone in which the period is evaluated. Defaults
+// to UTC. Example: Location("Europe/Paris").
+func Location(location string) PeriodOption {
+	return func(period *periodModel) {
+		period.Location = location
+	}
+}
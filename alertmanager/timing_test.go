@@ -0,0 +1,65 @@
+package alertmanager
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMuteTiming(t *testing.T) {
+	req := require.New(t)
+
+	timing := MuteTiming("off-hours")
+
+	req.Equal("off-hours", timing.Name())
+	req.Empty(timing.builder.TimeIntervals)
+}
+
+func TestMuteTimingPeriods(t *testing.T) {
+	req := require.New(t)
+
+	timing := MuteTiming("off-hours",
+		Period(
+			Times("00:00", "09:00"),
+			Times("18:00", "24:00"),
+			Weekdays("monday:friday"),
+			Location("Europe/Paris"),
+		),
+		Period(
+			Weekdays("saturday", "sunday"),
+			DaysOfMonth("1:5", "-1"),
+			Months("december"),
+			Years("2024:2025"),
+		),
+	)
+
+	req.Len(timing.builder.TimeIntervals, 2)
+
+	workdays := timing.builder.TimeIntervals[0]
+	req.Equal([]timeRange{{StartTime: "00:00", EndTime: "09:00"}, {StartTime: "18:00", EndTime: "24:00"}}, workdays.Times)
+	req.Equal([]string{"monday:friday"}, workdays.Weekdays)
+	req.Equal("Europe/Paris", workdays.Location)
+
+	weekends := timing.builder.TimeIntervals[1]
+	req.Equal([]string{"saturday", "sunday"}, weekends.Weekdays)
+	req.Equal([]string{"1:5", "-1"}, weekends.DaysOfMonth)
+	req.Equal([]string{"december"}, weekends.Months)
+	req.Equal([]string{"2024:2025"}, weekends.Years)
+}
+
+func TestMuteTimingMarshalJSON(t *testing.T) {
+	req := require.New(t)
+
+	timing := MuteTiming("maintenance", Period(Weekdays("sunday"), Times("02:00", "04:00")))
+
+	buf, err := json.Marshal(timing)
+
+	req.NoError(err)
+	req.JSONEq(`{
+  "name": "maintenance",
+  "time_intervals": [
+    {"times": [{"start_time": "02:00", "end_time": "04:00"}], "weekdays": ["sunday"]}
+  ]
+}`, string(buf))
+}
@@ -0,0 +1,101 @@
+package alertmanager
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/K-Phoen/sdk"
+)
+
+// ErrUnknownContactPoint is returned when a routing policy refers to an undefined contact point.
+var ErrUnknownContactPoint = errors.New("unknown contact point")
+
+// ErrUnknownTimeInterval is returned when a routing policy refers to an undefined mute timing.
+var ErrUnknownTimeInterval = errors.New("unknown time interval")
+
+// ErrUnreachablePolicy is returned when a routing policy can never match,
+// because a previous sibling matches every alert it would match.
+var ErrUnreachablePolicy = errors.New("unreachable routing policy")
+
+// Validate checks the routing tree of the manager:
+//   - policies can't refer to contact points or mute timings that aren't
+//     defined. This check is only done when the manager defines contact
+//     points (respectively mute timings), so that a routing tree can be
+//     validated on its own
+//   - policies can't be shadowed by a previous sibling that matches a
+//     subset of their matchers without Continue()
+func (manager *Manager) Validate() error {
+	config := manager.builder.Config
+
+	var contactPoints map[string]bool
+	if len(config.Receivers) != 0 {
+		contactPoints = make(map[string]bool, len(config.Receivers))
+		for _, receiver := range config.Receivers {
+			contactPoints[receiver.Name] = true
+		}
+	}
+
+	var timings map[string]bool
+	if len(config.MuteTimeIntervals) != 0 {
+		timings = make(map[string]bool, len(config.MuteTimeIntervals))
+		for _, interval := range config.MuteTimeIntervals {
+			timings[interval.Name] = true
+		}
+	}
+
+	if config.Route.Receiver != "" && contactPoints != nil && !contactPoints[config.Route.Receiver] {
+		return fmt.Errorf("default policy: %w '%s'", ErrUnknownContactPoint, config.Route.Receiver)
+	}
+
+	return validatePolicies("routes", config.Route.Routes, contactPoints, timings)
+}
+
+func validatePolicies(path string, policies []routingPolicyModel, contactPoints map[string]bool, timings map[string]bool) error {
+	for i, policy := range policies {
+		policyPath := fmt.Sprintf("%s[%d]", path, i)
+
+		if policy.Receiver != "" && contactPoints != nil && !contactPoints[policy.Receiver] {
+			return fmt.Errorf("%s: %w '%s'", policyPath, ErrUnknownContactPoint, policy.Receiver)
+		}
+
+		if timings != nil {
+			for _, name := range append(append([]string{}, policy.MuteTimeIntervals...), policy.ActiveTimeIntervals...) {
+				if !timings[name] {
+					return fmt.Errorf("%s: %w '%s'", policyPath, ErrUnknownTimeInterval, name)
+				}
+			}
+		}
+
+		for j, previous := range policies[:i] {
+			if !previous.Continue && matchersIncluded(previous.ObjectMatchers, policy.ObjectMatchers) {
+				return fmt.Errorf("%s: %w: every alert it matches is caught by %s[%d]", policyPath, ErrUnreachablePolicy, path, j)
+			}
+		}
+
+		if err := validatePolicies(policyPath+".routes", policy.Routes, contactPoints, timings); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// matchersIncluded tells if every matcher of subset is also in set, in
+// which case alerts matching set also match subset.
+func matchersIncluded(subset []sdk.AlertObjectMatcher, set []sdk.AlertObjectMatcher) bool {
+	for _, candidate := range subset {
+		found := false
+		for _, matcher := range set {
+			if matcher == candidate {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,129 @@
+package alertmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidRoutingTrees(t *testing.T) {
+	req := require.New(t)
+
+	manager := New(
+		ContactPoints(ContactPoint("default"), ContactPoint("team-a"), ContactPoint("team-a-pager")),
+		TimeIntervals(MuteTiming("off-hours")),
+		Routing(
+			Policy("team-a",
+				TagEq("owner", "team-a"),
+				Continue(),
+				Nested(
+					Policy("team-a-pager", TagEq("severity", "critical"), ActiveTimings("off-hours")),
+					Policy("", TagEq("severity", "info")),
+				),
+			),
+			// reachable, since the previous policy continues
+			Policy("default", TagEq("owner", "team-a"), TagEq("severity", "critical")),
+			Policy("default"),
+		),
+	)
+
+	req.NoError(manager.Validate())
+}
+
+func TestRoutingTreesCanBeValidatedWithoutContactPoints(t *testing.T) {
+	req := require.New(t)
+
+	manager := New(
+		DefaultContactPoint("defined-elsewhere"),
+		Routing(Policy("also-defined-elsewhere", MuteTimings("defined-elsewhere-too"))),
+	)
+
+	req.NoError(manager.Validate())
+}
+
+func TestUnknownContactPointsAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	manager := New(
+		ContactPoints(ContactPoint("team-a")),
+		Routing(
+			Policy("team-a", TagEq("owner", "team-a"), Nested(
+				Policy("team-b", TagEq("severity", "critical")),
+			)),
+		),
+	)
+
+	err := manager.Validate()
+
+	req.ErrorIs(err, ErrUnknownContactPoint)
+	req.Contains(err.Error(), "routes[0].routes[0]")
+	req.Contains(err.Error(), "team-b")
+}
+
+func TestUnknownDefaultContactPointIsRejected(t *testing.T) {
+	req := require.New(t)
+
+	manager := New(
+		ContactPoints(ContactPoint("team-a")),
+		DefaultContactPoint("team-b"),
+	)
+
+	req.ErrorIs(manager.Validate(), ErrUnknownContactPoint)
+}
+
+func TestUnknownTimeIntervalsAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	manager := New(
+		TimeIntervals(MuteTiming("off-hours")),
+		Routing(Policy("team-a", MuteTimings("maintenance"))),
+	)
+
+	req.ErrorIs(manager.Validate(), ErrUnknownTimeInterval)
+}
+
+func TestPoliciesAfterACatchAllArePointless(t *testing.T) {
+	req := require.New(t)
+
+	manager := New(
+		Routing(
+			Policy("team-a"),
+			Policy("team-b", TagEq("owner", "team-b")),
+		),
+	)
+
+	err := manager.Validate()
+
+	req.ErrorIs(err, ErrUnreachablePolicy)
+	req.Contains(err.Error(), "routes[1]")
+}
+
+func TestPoliciesShadowedByBroaderSiblingsAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	manager := New(
+		Routing(
+			Policy("team-a", TagEq("owner", "team-a")),
+			Policy("team-a-pager", TagEq("severity", "critical"), TagEq("owner", "team-a")),
+		),
+	)
+
+	req.ErrorIs(manager.Validate(), ErrUnreachablePolicy)
+}
+
+func TestInvalidRoutingTreesCanNotBeMarshalled(t *testing.T) {
+	req := require.New(t)
+
+	manager := New(
+		Routing(
+			Policy("team-a"),
+			Policy("team-b"),
+		),
+	)
+
+	_, err := manager.MarshalJSON()
+	req.ErrorIs(err, ErrUnreachablePolicy)
+
+	_, err = manager.MarshalIndentJSON()
+	req.ErrorIs(err, ErrUnreachablePolicy)
+}
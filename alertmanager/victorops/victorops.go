@@ -0,0 +1,70 @@
+package victorops
+
+import (
+	"github.com/K-Phoen/grabana/alertmanager"
+	"github.com/K-Phoen/sdk"
+)
+
+// Option represents an option that can be used to configure a "victorops"
+// contact point type.
+type Option func(contactType *victoropsType)
+
+// MessageType describes the kind of incident that is created in VictorOps.
+type MessageType string
+
+const (
+	Critical        MessageType = "CRITICAL"
+	Warning         MessageType = "WARNING"
+	Info            MessageType = "INFO"
+	Acknowledgement MessageType = "ACKNOWLEDGEMENT"
+	Recovery        MessageType = "RECOVERY"
+)
+
+type victoropsType struct {
+	builder *sdk.ContactPointType
+}
+
+// Webhook creates a VictorOps (Splunk On-Call) contact point type that sends
+// alerts to a REST endpoint integration.
+// See https://help.victorops.com/knowledge-base/rest-endpoint-integration-guide/
+func Webhook(url string, opts ...Option) alertmanager.ContactPointOption {
+	victorops := &victoropsType{
+		builder: &sdk.ContactPointType{
+			Type:     "victorops",
+			Settings: map[string]interface{}{},
+			SecureSettings: map[string]interface{}{
+				"url": url,
+			},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(victorops)
+	}
+
+	return func(contact *alertmanager.Contact) {
+		contact.Builder.GrafanaManagedReceivers = append(contact.Builder.GrafanaManagedReceivers, *victorops.builder)
+	}
+}
+
+// WithMessageType sets the kind of incident that is created. Grafana
+// defaults to Critical.
+func WithMessageType(messageType MessageType) Option {
+	return func(contactType *victoropsType) {
+		contactType.builder.Settings["messageType"] = string(messageType)
+	}
+}
+
+// Title defines a templated title for the incidents.
+func Title(templatedTitle string) Option {
+	return func(contactType *victoropsType) {
+		contactType.builder.Settings["title"] = templatedTitle
+	}
+}
+
+// Description defines a templated description for the incidents.
+func Description(description string) Option {
+	return func(contactType *victoropsType) {
+		contactType.builder.Settings["description"] = description
+	}
+}
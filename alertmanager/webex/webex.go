@@ -0,0 +1,53 @@
+package webex
+
+import (
+	"github.com/K-Phoen/grabana/alertmanager"
+	"github.com/K-Phoen/sdk"
+)
+
+// Option represents an option that can be used to configure a "webex"
+// contact point type.
+type Option func(contactType *webexType)
+
+type webexType struct {
+	builder *sdk.ContactPointType
+}
+
+// Bot creates a Cisco Webex contact point type that sends alerts to the
+// given room, as the bot identified by the given token.
+// See https://developer.webex.com/docs/bots
+func Bot(botToken string, roomID string, opts ...Option) alertmanager.ContactPointOption {
+	webex := &webexType{
+		builder: &sdk.ContactPointType{
+			Type: "webex",
+			Settings: map[string]interface{}{
+				"room_id": roomID,
+			},
+			SecureSettings: map[string]interface{}{
+				"bot_token": botToken,
+			},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(webex)
+	}
+
+	return func(contact *alertmanager.Contact) {
+		contact.Builder.GrafanaManagedReceivers = append(contact.Builder.GrafanaManagedReceivers, *webex.builder)
+	}
+}
+
+// APIURL overrides the URL of Webex's API. Defaults to https://webexapis.com/v1/messages.
+func APIURL(url string) Option {
+	return func(contactType *webexType) {
+		contactType.builder.Settings["api_url"] = url
+	}
+}
+
+// Message defines the templated message that will be sent.
+func Message(message string) Option {
+	return func(contactType *webexType) {
+		contactType.builder.Settings["message"] = message
+	}
+}
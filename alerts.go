@@ -26,7 +26,10 @@ type alertRef struct {
 	RuleGroup string
 }
 
-// ConfigureAlertManager updates the alert manager configuration.
+// ConfigureAlertManager replaces the whole alert manager configuration.
+// Contact points, notification policies and message templates can also be
+// provisioned individually, see UpsertContactPoint, SetNotificationPolicies
+// and UpsertMessageTemplate.
 func (client *Client) ConfigureAlertManager(ctx context.Context, manager *alertmanager.Manager) error {
 	buf, err := manager.MarshalIndentJSON()
 	if err != nil {
@@ -49,19 +52,8 @@ func (client *Client) ConfigureAlertManager(ctx context.Context, manager *alertm
 
 // ListAlertsForDashboard fetches a list of alerts linked to the given dashboard.
 func (client *Client) ListAlertsForDashboard(ctx context.Context, dashboardUID string) ([]alertRef, error) {
-	resp, err := client.get(ctx, "/api/v1/provisioning/alert-rules")
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, client.httpError(resp)
-	}
-
 	var alerts []sdk.NgAlert
-	if err := decodeJSON(resp.Body, &alerts); err != nil {
+	if err := client.listAlertRules(ctx, &alerts); err != nil {
 		return nil, err
 	}
 
@@ -84,6 +76,22 @@ func (client *Client) ListAlertsForDashboard(ctx context.Context, dashboardUID s
 	return refs, nil
 }
 
+// listAlertRules fetches every provisioned alert rule and decodes them into the given value.
+func (client *Client) listAlertRules(ctx context.Context, rules interface{}) error {
+	resp, err := client.get(ctx, "/api/v1/provisioning/alert-rules")
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return decodeJSON(resp.Body, rules)
+}
+
 func (client *Client) UpsertAlert(ctx context.Context, alertDefinition alert.Alert, datasourcesMap map[string]string) error {
 	err := alertDefinition.HookDatasource(datasourcesMap)
 	if err != nil {
@@ -0,0 +1,229 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ErrAnnotationNotFound is returned when the given annotation can not be found.
+var ErrAnnotationNotFound = errors.New("annotation not found")
+
+// Annotation represents an annotation, shown on the graphs of a dashboard.
+// Annotations without a TimeEnd mark a point in time, the others a region.
+// Annotations without a DashboardUID are organization-wide: they can be
+// shown on any dashboard, usually by filtering on their tags.
+// See https://grafana.com/docs/grafana/latest/developers/http_api/annotations/
+type Annotation struct {
+	ID           uint
+	DashboardUID string
+	PanelID      uint
+	Time         time.Time
+	TimeEnd      time.Time
+	Tags         []string
+	Text         string
+	// Login of the user who created the annotation. Read-only.
+	Login string
+}
+
+// annotationModel is the representation of annotations used by Grafana.
+type annotationModel struct {
+	ID           uint     `json:"id,omitempty"`
+	DashboardUID string   `json:"dashboardUID,omitempty"`
+	PanelID      uint     `json:"panelId,omitempty"`
+	Time         int64    `json:"time,omitempty"`
+	TimeEnd      int64    `json:"timeEnd,omitempty"`
+	Tags         []string `json:"tags"`
+	Text         string   `json:"text"`
+	Login        string   `json:"login,omitempty"`
+}
+
+func (annotation Annotation) toModel() annotationModel {
+	model := annotationModel{
+		DashboardUID: annotation.DashboardUID,
+		PanelID:      annotation.PanelID,
+		Tags:         annotation.Tags,
+		Text:         annotation.Text,
+	}
+	if model.Tags == nil {
+		model.Tags = []string{}
+	}
+	if !annotation.Time.IsZero() {
+		model.Time = annotation.Time.UnixMilli()
+	}
+	if !annotation.TimeEnd.IsZero() {
+		model.TimeEnd = annotation.TimeEnd.UnixMilli()
+	}
+
+	return model
+}
+
+func (model annotationModel) toAnnotation() Annotation {
+	annotation := Annotation{
+		ID:           model.ID,
+		DashboardUID: model.DashboardUID,
+		PanelID:      model.PanelID,
+		Tags:         model.Tags,
+		Text:         model.Text,
+		Login:        model.Login,
+	}
+	if model.Time != 0 {
+		annotation.Time = time.UnixMilli(model.Time)
+	}
+	// point annotations are returned with timeEnd == time
+	if model.TimeEnd != 0 && model.TimeEnd != model.Time {
+		annotation.TimeEnd = time.UnixMilli(model.TimeEnd)
+	}
+
+	return annotation
+}
+
+// AnnotationQuery describes the criteria used to find annotations. Empty
+// criteria are ignored.
+type AnnotationQuery struct {
+	DashboardUID string
+	PanelID      uint
+	// Tags only keeps annotations having all the given tags, or any of them
+	// if MatchAny is true.
+	Tags     []string
+	MatchAny bool
+	From     time.Time
+	To       time.Time
+	// Limit is the maximum number of annotations returned. Defaults to 100.
+	Limit int
+}
+
+func (query AnnotationQuery) values() url.Values {
+	values := url.Values{}
+	values.Set("type", "annotation")
+
+	if query.DashboardUID != "" {
+		values.Set("dashboardUID", query.DashboardUID)
+	}
+	if query.PanelID != 0 {
+		values.Set("panelId", strconv.FormatUint(uint64(query.PanelID), 10))
+	}
+	for _, tag := range query.Tags {
+		values.Add("tags", tag)
+	}
+	if query.MatchAny {
+		values.Set("matchAny", "true")
+	}
+	if !query.From.IsZero() {
+		values.Set("from", strconv.FormatInt(query.From.UnixMilli(), 10))
+	}
+	if !query.To.IsZero() {
+		values.Set("to", strconv.FormatInt(query.To.UnixMilli(), 10))
+	}
+	if query.Limit > 0 {
+		values.Set("limit", strconv.Itoa(query.Limit))
+	}
+
+	return values
+}
+
+// CreateAnnotation creates an annotation and returns it, with its ID set.
+// Annotations without a Time are created at the current time.
+func (client *Client) CreateAnnotation(ctx context.Context, annotation Annotation) (*Annotation, error) {
+	buf, err := json.Marshal(annotation.toModel())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPost, "/api/annotations", buf)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	response := struct {
+		ID uint `json:"id"`
+	}{}
+	if err := decodeJSON(resp.Body, &response); err != nil {
+		return nil, err
+	}
+
+	annotation.ID = response.ID
+
+	return &annotation, nil
+}
+
+// UpdateAnnotation replaces the annotation identified by annotation.ID.
+func (client *Client) UpdateAnnotation(ctx context.Context, annotation Annotation) error {
+	buf, err := json.Marshal(annotation.toModel())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPut, fmt.Sprintf("/api/annotations/%d", annotation.ID), buf)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrAnnotationNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
+
+// DeleteAnnotation deletes an annotation given its ID.
+func (client *Client) DeleteAnnotation(ctx context.Context, id uint) error {
+	resp, err := client.delete(ctx, fmt.Sprintf("/api/annotations/%d", id))
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrAnnotationNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
+
+// ListAnnotations finds the annotations matching the given query, most
+// recent first.
+func (client *Client) ListAnnotations(ctx context.Context, query AnnotationQuery) ([]Annotation, error) {
+	resp, err := client.get(ctx, "/api/annotations?"+query.values().Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var models []annotationModel
+	if err := decodeJSON(resp.Body, &models); err != nil {
+		return nil, err
+	}
+
+	annotations := make([]Annotation, 0, len(models))
+	for _, model := range models {
+		annotations = append(annotations, model.toAnnotation())
+	}
+
+	return annotations, nil
+}
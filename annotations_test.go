@@ -0,0 +1,146 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegionAnnotationsCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	var payload map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodPost, r.Method)
+		req.Equal("/api/annotations", r.URL.Path)
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+
+		_, _ = fmt.Fprintln(w, `{"message": "Annotation added", "id": 42}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	annotation, err := client.CreateAnnotation(context.TODO(), Annotation{
+		DashboardUID: "dash-uid",
+		PanelID:      2,
+		Time:         time.UnixMilli(1000),
+		TimeEnd:      time.UnixMilli(2000),
+		Tags:         []string{"deploy"},
+		Text:         "Deployed v1.2.3",
+	})
+
+	req.NoError(err)
+	req.Equal(uint(42), annotation.ID)
+	req.Equal(map[string]interface{}{
+		"dashboardUID": "dash-uid",
+		"panelId":      float64(2),
+		"time":         float64(1000),
+		"timeEnd":      float64(2000),
+		"tags":         []interface{}{"deploy"},
+		"text":         "Deployed v1.2.3",
+	}, payload)
+}
+
+func TestPointAnnotationsCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	var payload map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+
+		_, _ = fmt.Fprintln(w, `{"message": "Annotation added", "id": 42}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.CreateAnnotation(context.TODO(), Annotation{Text: "Deployed"})
+
+	req.NoError(err)
+	req.NotContains(payload, "time")
+	req.NotContains(payload, "timeEnd")
+	req.NotContains(payload, "dashboardUID")
+	req.Equal([]interface{}{}, payload["tags"])
+}
+
+func TestAnnotationsCanBeUpdated(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodPut, r.Method)
+		req.Equal("/api/annotations/42", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `{"message": "Annotation updated"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.UpdateAnnotation(context.TODO(), Annotation{ID: 42, Text: "Rolled back"})
+
+	req.NoError(err)
+}
+
+func TestDeletingAnUnknownAnnotationFailsCleanly(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodDelete, r.Method)
+		req.Equal("/api/annotations/42", r.URL.Path)
+
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.DeleteAnnotation(context.TODO(), 42)
+
+	req.ErrorIs(err, ErrAnnotationNotFound)
+}
+
+func TestAnnotationsCanBeListed(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		req.Equal("annotation", query.Get("type"))
+		req.Equal("dash-uid", query.Get("dashboardUID"))
+		req.Equal("2", query.Get("panelId"))
+		req.Equal([]string{"deploy", "prod"}, query["tags"])
+		req.Equal("true", query.Get("matchAny"))
+		req.Equal("1000", query.Get("from"))
+		req.Equal("5000", query.Get("to"))
+		req.Equal("10", query.Get("limit"))
+
+		_, _ = fmt.Fprintln(w, `[
+  {"id": 2, "dashboardUID": "dash-uid", "panelId": 2, "time": 3000, "timeEnd": 4000, "tags": ["deploy"], "text": "Region", "login": "admin"},
+  {"id": 1, "dashboardUID": "dash-uid", "panelId": 2, "time": 2000, "timeEnd": 2000, "tags": ["prod"], "text": "Point", "login": "admin"}
+]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	annotations, err := client.ListAnnotations(context.TODO(), AnnotationQuery{
+		DashboardUID: "dash-uid",
+		PanelID:      2,
+		Tags:         []string{"deploy", "prod"},
+		MatchAny:     true,
+		From:         time.UnixMilli(1000),
+		To:           time.UnixMilli(5000),
+		Limit:        10,
+	})
+
+	req.NoError(err)
+	req.Len(annotations, 2)
+	req.Equal(uint(2), annotations[0].ID)
+	req.Equal(int64(4000), annotations[0].TimeEnd.UnixMilli())
+	req.Equal("admin", annotations[0].Login)
+	req.True(annotations[1].TimeEnd.IsZero(), "point annotations have no end")
+}
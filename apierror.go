@@ -0,0 +1,148 @@
+package grabana
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// APIError describes an unexpected response returned by Grafana's HTTP API.
+// It can be extracted from any error returned by the client with errors.As.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Method and Path describe the request that failed.
+	Method string
+	Path   string
+	// Message and Status are parsed from the JSON body Grafana usually sends
+	// along with errors. Status holds machine-friendly values such as
+	// "version-mismatch" or "name-exists".
+	Message string
+	Status  string
+	// Body is the raw response body.
+	Body []byte
+}
+
+func (err *APIError) Error() string {
+	details := err.Message
+	if details == "" {
+		details = strings.TrimSpace(string(err.Body))
+	}
+
+	request := ""
+	if err.Method != "" {
+		request = fmt.Sprintf(" (%s %s)", err.Method, err.Path)
+	}
+
+	return fmt.Sprintf("could not query grafana%s: %s (HTTP status %d)", request, details, err.StatusCode)
+}
+
+func newAPIError(resp *http.Response) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       body,
+	}
+
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Path = resp.Request.URL.Path
+	}
+
+	payload := struct {
+		Message string `json:"message"`
+		Status  string `json:"status"`
+	}{}
+	if json.Unmarshal(body, &payload) == nil {
+		apiErr.Message = payload.Message
+		apiErr.Status = payload.Status
+	}
+
+	return apiErr
+}
+
+// notFoundErrors lists the sentinel errors meaning that a resource does not
+// exist.
+var notFoundErrors = []error{
+	ErrDashboardNotFound,
+	ErrAlertNotFound,
+	ErrFolderNotFound,
+	ErrDatasourceNotFound,
+	ErrAPIKeyNotFound,
+	ErrOrgNotFound,
+	ErrServiceAccountNotFound,
+	ErrDashboardVersionNotFound,
+	ErrAnnotationNotFound,
+	ErrLibraryPanelNotFound,
+	ErrTeamNotFound,
+	ErrUserNotFound,
+	ErrSnapshotNotFound,
+	ErrPlaylistNotFound,
+	ErrContactPointNotFound,
+	ErrMessageTemplateNotFound,
+	ErrMuteTimingNotFound,
+	ErrRuleGroupNotFound,
+}
+
+// IsNotFound tells whether the given error means that the requested resource
+// does not exist.
+func IsNotFound(err error) bool {
+	for _, notFound := range notFoundErrors {
+		if errors.Is(err, notFound) {
+			return true
+		}
+	}
+
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+// IsConflict tells whether the given error was caused by a conflict, such as
+// an outdated version or a resource that already exists.
+func IsConflict(err error) bool {
+	return hasStatusCode(err, http.StatusConflict)
+}
+
+// IsPreconditionFailed tells whether the given error was caused by a failed
+// precondition, such as a dashboard with the same name in the same folder.
+func IsPreconditionFailed(err error) bool {
+	return hasStatusCode(err, http.StatusPreconditionFailed)
+}
+
+// IsUnauthorized tells whether the given error was caused by missing or
+// invalid credentials.
+func IsUnauthorized(err error) bool {
+	return hasStatusCode(err, http.StatusUnauthorized)
+}
+
+// IsForbidden tells whether the given error was caused by insufficient
+// permissions.
+func IsForbidden(err error) bool {
+	return hasStatusCode(err, http.StatusForbidden)
+}
+
+// IsServerError tells whether the given error was caused by a server-side
+// failure (5xx status code).
+func IsServerError(err error) bool {
+	apiErr := &APIError{}
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	return apiErr.StatusCode >= http.StatusInternalServerError
+}
+
+func hasStatusCode(err error, statusCode int) bool {
+	apiErr := &APIError{}
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	return apiErr.StatusCode == statusCode
+}
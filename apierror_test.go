@@ -0,0 +1,80 @@
+package grabana
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailedRequestsReturnAnAPIError(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		_, _ = fmt.Fprintln(w, `{"message": "A dashboard with the same name in the folder already exists", "status": "name-exists"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.CreateFolder(context.TODO(), "Folder")
+	req.Error(err)
+
+	apiErr := &APIError{}
+	req.True(errors.As(err, &apiErr))
+	req.Equal(http.StatusPreconditionFailed, apiErr.StatusCode)
+	req.Equal(http.MethodPost, apiErr.Method)
+	req.Equal("/api/folders", apiErr.Path)
+	req.Equal("A dashboard with the same name in the folder already exists", apiErr.Message)
+	req.Equal("name-exists", apiErr.Status)
+
+	req.True(IsPreconditionFailed(err))
+	req.False(IsConflict(err))
+	req.Contains(err.Error(), "HTTP status 412")
+}
+
+func TestAPIErrorsWithoutJSONBodyKeepTheRawBody(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = fmt.Fprintln(w, `upstream unavailable`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.ListFolders(context.TODO())
+
+	req.True(IsServerError(err))
+	req.Contains(err.Error(), "upstream unavailable")
+}
+
+func TestErrorHelpers(t *testing.T) {
+	testCases := []struct {
+		name      string
+		err       error
+		predicate func(error) bool
+		expected  bool
+	}{
+		{name: "not found sentinel", err: ErrDashboardNotFound, predicate: IsNotFound, expected: true},
+		{name: "wrapped not found sentinel", err: fmt.Errorf("wrapped: %w", ErrAlertNotFound), predicate: IsNotFound, expected: true},
+		{name: "not found status", err: &APIError{StatusCode: http.StatusNotFound}, predicate: IsNotFound, expected: true},
+		{name: "conflict", err: fmt.Errorf("wrapped: %w", &APIError{StatusCode: http.StatusConflict}), predicate: IsConflict, expected: true},
+		{name: "unauthorized", err: &APIError{StatusCode: http.StatusUnauthorized}, predicate: IsUnauthorized, expected: true},
+		{name: "forbidden", err: &APIError{StatusCode: http.StatusForbidden}, predicate: IsForbidden, expected: true},
+		{name: "unrelated error", err: errors.New("boom"), predicate: IsNotFound, expected: false},
+		{name: "other status", err: &APIError{StatusCode: http.StatusForbidden}, predicate: IsConflict, expected: false},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.predicate(tc.err))
+		})
+	}
+}
@@ -50,6 +50,8 @@ type APIKey struct {
 }
 
 // CreateAPIKey creates a new API key.
+//
+// Deprecated: API keys are removed from recent Grafana versions. Use service accounts instead.
 func (client *Client) CreateAPIKey(ctx context.Context, request CreateAPIKeyRequest) (string, error) {
 	buf, err := json.Marshal(request)
 	if err != nil {
@@ -78,6 +80,8 @@ func (client *Client) CreateAPIKey(ctx context.Context, request CreateAPIKeyRequ
 }
 
 // DeleteAPIKeyByName deletes an API key given its name.
+//
+// Deprecated: API keys are removed from recent Grafana versions. Use service accounts instead.
 func (client *Client) DeleteAPIKeyByName(ctx context.Context, name string) error {
 	apiKeys, err := client.APIKeys(ctx)
 	if err != nil {
@@ -107,6 +111,8 @@ func (client *Client) DeleteAPIKeyByName(ctx context.Context, name string) error
 }
 
 // APIKeys lists active API keys.
+//
+// Deprecated: API keys are removed from recent Grafana versions. Use service accounts instead.
 func (client *Client) APIKeys(ctx context.Context) (map[string]APIKey, error) {
 	resp, err := client.get(ctx, "/api/auth/keys")
 	if err != nil {
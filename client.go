@@ -4,9 +4,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 )
 
 // Option represents an option that can be used to configure a client.
@@ -19,6 +19,9 @@ type Client struct {
 	http             *http.Client
 	host             string
 	requestModifiers []requestModifier
+	retry            *retryPolicy
+	limiter          *rateLimiter
+	orgID            uint
 }
 
 // NewClient creates a new Grafana HTTP client, using an API token.
@@ -53,53 +56,76 @@ func WithBasicAuth(username string, password string) Option {
 	}
 }
 
+// WithOrgID sets up the client to act on the given organization, instead of
+// the user's current one.
+func WithOrgID(orgID uint) Option {
+	return func(client *Client) {
+		client.orgID = orgID
+	}
+}
+
+// WithOrg returns a copy of the client that acts on the given organization.
+// The original client is left untouched.
+func (client *Client) WithOrg(orgID uint) *Client {
+	derived := *client
+	derived.orgID = orgID
+
+	return &derived
+}
+
 func (client *Client) modifyRequest(request *http.Request) {
 	for _, modifier := range client.requestModifiers {
 		modifier(request)
 	}
-}
 
-func (client Client) httpError(resp *http.Response) error {
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
+	if client.orgID != 0 {
+		request.Header.Set("X-Grafana-Org-Id", strconv.FormatUint(uint64(client.orgID), 10))
 	}
+}
 
-	return fmt.Errorf("could not query grafana: %s (HTTP status %d)", body, resp.StatusCode)
+func (client Client) httpError(resp *http.Response) error {
+	return newAPIError(resp)
 }
 
 func (client Client) delete(ctx context.Context, path string) (*http.Response, error) {
-	request, err := http.NewRequestWithContext(ctx, http.MethodDelete, client.url(path), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	client.modifyRequest(request)
-
-	return client.http.Do(request)
+	return client.do(ctx, http.MethodDelete, path, nil, true)
 }
 
 func (client Client) sendJSON(ctx context.Context, method string, path string, body []byte) (*http.Response, error) {
-	request, err := http.NewRequestWithContext(ctx, method, client.url(path), bytes.NewReader(body))
-	if err != nil {
-		return nil, err
-	}
-
-	request.Header.Add("Content-Type", "application/json")
-	client.modifyRequest(request)
+	return client.do(ctx, method, path, body, isIdempotent(method))
+}
 
-	return client.http.Do(request)
+// sendRetryableJSON is like sendJSON, for requests that can safely be sent
+// again whatever their method.
+func (client Client) sendRetryableJSON(ctx context.Context, method string, path string, body []byte) (*http.Response, error) {
+	return client.do(ctx, method, path, body, true)
 }
 
 func (client Client) get(ctx context.Context, path string) (*http.Response, error) {
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, client.url(path), nil)
-	if err != nil {
-		return nil, err
-	}
+	return client.do(ctx, http.MethodGet, path, nil, true)
+}
+
+func (client Client) do(ctx context.Context, method string, path string, body []byte, retryable bool) (*http.Response, error) {
+	newRequest := func() (*http.Request, error) {
+		var bodyReader io.Reader
+		if body != nil {
+			bodyReader = bytes.NewReader(body)
+		}
+
+		request, err := http.NewRequestWithContext(ctx, method, client.url(path), bodyReader)
+		if err != nil {
+			return nil, err
+		}
 
-	client.modifyRequest(request)
+		if body != nil {
+			request.Header.Add("Content-Type", "application/json")
+		}
+		client.modifyRequest(request)
+
+		return request, nil
+	}
 
-	return client.http.Do(request)
+	return client.doWithRetries(ctx, retryable, newRequest)
 }
 
 func (client Client) url(path string) string {
@@ -1,21 +1,105 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"net/http"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/K-Phoen/grabana"
+	"github.com/K-Phoen/grabana/dashboard"
 	"github.com/K-Phoen/grabana/decoder"
+	"github.com/K-Phoen/grabana/playlist"
 	"github.com/spf13/cobra"
 )
 
+// ErrOrgsRequireBasicAuth is returned when dashboards are applied to several
+// organizations without basic auth credentials: API tokens are bound to a
+// single organization.
+var ErrOrgsRequireBasicAuth = errors.New("--org requires server admin credentials, given with --user and --password")
+
 type applyOpts struct {
 	inputYAML         string
 	destinationFolder string
 	grafanaHost       string
 	grafanaToken      string
+	grafanaUser       string
+	grafanaPassword   string
+	prune             bool
+	orgs              []string
+	message           string
+}
+
+// dashboardSource is a decoded YAML dashboard, along with the folder it
+// belongs to: the root folder, found by its exact title, and an optional
+// path of sub-folders nested within it.
+type dashboardSource struct {
+	path      string
+	folder    string
+	subFolder string
+	dashboard dashboard.Builder
+}
+
+// folderPath describes the folder of the dashboard, for summaries and
+// error messages.
+func (source dashboardSource) folderPath() string {
+	if source.subFolder == "" {
+		return source.folder
+	}
+
+	return source.folder + "/" + source.subFolder
+}
+
+// playlistSource is a decoded YAML playlist.
+type playlistSource struct {
+	path     string
+	playlist playlist.Builder
+}
+
+// applySources holds everything decoded from the input, by kind.
+type applySources struct {
+	dashboards []dashboardSource
+	playlists  []playlistSource
+}
+
+// applySummary keeps track of what an apply run did, one entry per
+// "folder/title" dashboard and per "playlist:name" playlist.
+type applySummary struct {
+	created   []string
+	updated   []string
+	unchanged []string
+	deleted   []string
+}
+
+func (summary applySummary) String() string {
+	var buffer strings.Builder
+
+	for _, section := range []struct {
+		label string
+		items []string
+	}{
+		{label: "created", items: summary.created},
+		{label: "updated", items: summary.updated},
+		{label: "unchanged", items: summary.unchanged},
+		{label: "deleted", items: summary.deleted},
+	} {
+		for _, item := range section.items {
+			buffer.WriteString(fmt.Sprintf("%s: %s\n", section.label, item))
+		}
+	}
+
+	buffer.WriteString(fmt.Sprintf(
+		"%d created, %d updated, %d unchanged, %d deleted\n",
+		len(summary.created), len(summary.updated), len(summary.unchanged), len(summary.deleted),
+	))
+
+	return buffer.String()
 }
 
 func Apply() *cobra.Command {
@@ -23,16 +107,37 @@ func Apply() *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:   "apply",
-		Short: "Apply a YAML dashboard",
+		Short: "Apply a YAML dashboard, or a directory of YAML dashboards",
+		Long: `Apply a YAML dashboard, or a directory of YAML dashboards.
+
+When the input is a directory, it is walked recursively: dashboards at its
+root are created in the folder given by --folder, dashboards in
+sub-directories are created in nested folders mirroring the sub-directory
+path: "team/databases" becomes a "databases" folder within a "team" folder,
+itself within the --folder one. The --folder title is always used as is,
+even when it contains slashes.
+
+Files having a top-level "kind: playlist" key describe playlists. They are
+applied once every dashboard is, and are never pruned.
+
+With --org, the same dashboards are applied to each of the given
+organizations. Switching organizations requires server admin credentials,
+given with --user and --password: API tokens are bound to a single
+organization.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return applyYAML(opts)
 		},
 	}
 
-	cmd.Flags().StringVarP(&opts.inputYAML, "input", "i", "", "YAML file used as input")
+	cmd.Flags().StringVarP(&opts.inputYAML, "input", "i", "", "YAML file or directory used as input")
 	cmd.Flags().StringVarP(&opts.destinationFolder, "folder", "f", "", "Folder in which the dashboard will be created")
 	cmd.Flags().StringVarP(&opts.grafanaHost, "grafana", "g", "", "Grafana host. Example: http://grafana-host:3000")
 	cmd.Flags().StringVarP(&opts.grafanaToken, "token", "t", "", "Grafana API token")
+	cmd.Flags().StringVar(&opts.grafanaUser, "user", "", "Grafana user, authenticating with basic auth instead of a token")
+	cmd.Flags().StringVar(&opts.grafanaPassword, "password", "", "Password of the Grafana user")
+	cmd.Flags().BoolVar(&opts.prune, "prune", false, "Delete dashboards from the managed folders when they have no YAML source")
+	cmd.Flags().StringVarP(&opts.message, "message", "m", "", "Message attached to the dashboard versions created by this run. Example: a commit SHA")
+	cmd.Flags().StringSliceVar(&opts.orgs, "org", nil, "Name of an organization to apply the dashboards to. Can be repeated. Defaults to the current organization")
 
 	_ = cmd.MarkFlagFilename("input", "yaml", "yml")
 
@@ -40,40 +145,303 @@ func Apply() *cobra.Command {
 	_ = cmd.MarkFlagRequired("folder")
 	_ = cmd.MarkFlagRequired("grafana")
 
+	cmd.MarkFlagsMutuallyExclusive("token", "user")
+	cmd.MarkFlagsRequiredTogether("user", "password")
+
 	return cmd
 }
 
 func applyYAML(opts applyOpts) error {
+	if len(opts.orgs) != 0 && opts.grafanaUser == "" {
+		return ErrOrgsRequireBasicAuth
+	}
+
 	ctx := context.Background()
-	client := grabanaClient(opts)
 
-	file, err := os.Open(opts.inputYAML)
+	client := grabanaClient(opts.grafanaHost, opts.grafanaToken)
+	if opts.grafanaUser != "" {
+		client = grabana.NewClient(&http.Client{}, opts.grafanaHost, grabana.WithBasicAuth(opts.grafanaUser, opts.grafanaPassword))
+	}
+
+	// decode everything before touching Grafana, so that an invalid file
+	// doesn't leave a half-applied tree behind.
+	sources, err := collectSources(opts.inputYAML, opts.destinationFolder)
+	if err != nil {
+		return err
+	}
+
+	if len(opts.orgs) == 0 {
+		summary, err := applyAll(ctx, client, sources, opts)
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(summary.String())
+
+		return nil
+	}
+
+	for i, orgName := range opts.orgs {
+		org, err := client.GetOrgByName(ctx, orgName)
+		if err != nil {
+			return fmt.Errorf("could not find organization '%s': %w", orgName, err)
+		}
+
+		// applying a dashboard alters its builder (IDs, datasource UIDs, …)
+		// so each organization gets freshly decoded ones.
+		if i > 0 {
+			sources, err = collectSources(opts.inputYAML, opts.destinationFolder)
+			if err != nil {
+				return err
+			}
+		}
+
+		summary, err := applyAll(ctx, client.WithOrg(org.ID), sources, opts)
+		if err != nil {
+			return fmt.Errorf("could not apply dashboards to organization '%s': %w", orgName, err)
+		}
+
+		fmt.Printf("organization %s:\n%s", orgName, summary.String())
+	}
+
+	return nil
+}
+
+func applyAll(ctx context.Context, client *grabana.Client, sources applySources, opts applyOpts) (applySummary, error) {
+	summary, err := applyDashboards(ctx, client, sources.dashboards, opts)
+	if err != nil {
+		return summary, err
+	}
+
+	// playlists are applied last, as they reference dashboards
+	for _, source := range sources.playlists {
+		if err := applyPlaylist(ctx, client, source, &summary); err != nil {
+			return summary, err
+		}
+	}
+
+	return summary, nil
+}
+
+func applyPlaylist(ctx context.Context, client *grabana.Client, source playlistSource, summary *applySummary) error {
+	model := source.playlist.Internal()
+	item := "playlist:" + model.Name
+
+	var err error
+	if model.UID != "" {
+		_, err = client.GetPlaylistByUID(ctx, model.UID)
+	} else {
+		_, err = client.GetPlaylistByName(ctx, model.Name)
+	}
+	if err != nil && !errors.Is(err, grabana.ErrPlaylistNotFound) {
+		return fmt.Errorf("could not fetch playlist from '%s': %w", source.path, err)
+	}
+	exists := err == nil
+
+	if _, err := client.UpsertPlaylist(ctx, source.playlist); err != nil {
+		return fmt.Errorf("could not apply playlist from '%s': %w", source.path, err)
+	}
+
+	if exists {
+		summary.updated = append(summary.updated, item)
+	} else {
+		summary.created = append(summary.created, item)
+	}
+
+	return nil
+}
+
+func applyDashboards(ctx context.Context, client *grabana.Client, sources []dashboardSource, opts applyOpts) (applySummary, error) {
+	var err error
+
+	summary := applySummary{}
+	folders := map[string]*grabana.Folder{}
+	appliedTitles := map[string]map[string]bool{}
+
+	for _, source := range sources {
+		folderPath := source.folderPath()
+
+		folder, ok := folders[folderPath]
+		if !ok {
+			folder, err = ensureFolder(ctx, client, source)
+			if err != nil {
+				return summary, fmt.Errorf("could not find or create folder '%s': %w", folderPath, err)
+			}
+
+			folders[folderPath] = folder
+			appliedTitles[folderPath] = map[string]bool{}
+		}
+
+		title := source.dashboard.Internal().Title
+		item := folderPath + "/" + title
+
+		if appliedTitles[folderPath][title] {
+			return summary, fmt.Errorf("could not apply dashboard from '%s': dashboard '%s' is defined more than once", source.path, item)
+		}
+		appliedTitles[folderPath][title] = true
+
+		diff, err := client.DiffDashboard(ctx, folder, source.dashboard)
+		if err != nil {
+			return summary, fmt.Errorf("could not compare dashboard from '%s': %w", source.path, err)
+		}
+
+		if diff.Exists && !diff.HasChanges() {
+			// permissions aren't part of the diff: they are enforced anyway
+			if err := client.EnforceDashboardPermissions(ctx, diff.UID, source.dashboard); err != nil {
+				return summary, fmt.Errorf("could not set permissions of dashboard from '%s': %w", source.path, err)
+			}
+
+			summary.unchanged = append(summary.unchanged, item)
+			continue
+		}
+
+		if _, err := client.UpsertDashboard(ctx, folder, source.dashboard, grabana.CommitMessage(opts.message)); err != nil {
+			return summary, fmt.Errorf("could not apply dashboard from '%s': %w", source.path, err)
+		}
+
+		if diff.Exists {
+			summary.updated = append(summary.updated, item)
+		} else {
+			summary.created = append(summary.created, item)
+		}
+	}
+
+	if opts.prune {
+		for _, folderTitle := range sortedFolderTitles(folders) {
+			dashboards, err := client.ListDashboardsInFolder(ctx, folders[folderTitle])
+			if err != nil {
+				return summary, fmt.Errorf("could not list dashboards in folder '%s': %w", folderTitle, err)
+			}
+
+			for _, dash := range dashboards {
+				if appliedTitles[folderTitle][dash.Title] {
+					continue
+				}
+
+				if err := client.DeleteDashboard(ctx, dash.UID); err != nil {
+					return summary, fmt.Errorf("could not delete dashboard '%s/%s': %w", folderTitle, dash.Title, err)
+				}
+
+				summary.deleted = append(summary.deleted, folderTitle+"/"+dash.Title)
+			}
+		}
+	}
+
+	return summary, nil
+}
+
+// ensureFolder finds or creates the folder of a dashboard. The root folder
+// is found by its exact title, only sub-folders are walked as a path.
+func ensureFolder(ctx context.Context, client *grabana.Client, source dashboardSource) (*grabana.Folder, error) {
+	root, err := client.FindOrCreateFolder(ctx, source.folder)
+	if err != nil || source.subFolder == "" {
+		return root, err
+	}
+
+	return client.EnsureChildFolderPath(ctx, root, source.subFolder)
+}
+
+// findFolder finds the folder of a dashboard the same way ensureFolder does,
+// without creating anything.
+func findFolder(ctx context.Context, client *grabana.Client, source dashboardSource) (*grabana.Folder, error) {
+	root, err := client.GetFolderByTitle(ctx, source.folder)
+	if err != nil || source.subFolder == "" {
+		return root, err
+	}
+
+	return client.GetChildFolderByPath(ctx, root, source.subFolder)
+}
+
+func collectSources(input string, rootFolder string) (applySources, error) {
+	sources := applySources{}
+
+	info, err := os.Stat(input)
 	if err != nil {
-		return fmt.Errorf("could not open input file '%s': %w", opts.inputYAML, err)
+		return sources, fmt.Errorf("could not open input '%s': %w", input, err)
+	}
+
+	if !info.IsDir() {
+		return sources, sources.decode(input, rootFolder, "")
 	}
 
-	dashboard, err := decoder.UnmarshalYAML(file)
+	err = filepath.WalkDir(input, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !isYAMLFile(path) {
+			return nil
+		}
+
+		subFolder := ""
+		relativeDir, err := filepath.Rel(input, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		if relativeDir != "." {
+			subFolder = filepath.ToSlash(relativeDir)
+		}
+
+		return sources.decode(path, rootFolder, subFolder)
+	})
+
+	return sources, err
+}
+
+// decode decodes a YAML file according to its kind, and adds it to the
+// sources.
+func (sources *applySources) decode(path string, folder string, subFolder string) error {
+	content, err := os.ReadFile(path)
 	if err != nil {
-		return fmt.Errorf("could not decode input file '%s': %w", opts.inputYAML, err)
+		return fmt.Errorf("could not open input file '%s': %w", path, err)
 	}
 
-	folder, err := client.FindOrCreateFolder(ctx, opts.destinationFolder)
+	kind, err := decoder.DetectKind(content)
 	if err != nil {
-		return fmt.Errorf("could not find or create folder '%s': %w", opts.destinationFolder, err)
+		return fmt.Errorf("could not decode input file '%s': %w", path, err)
 	}
 
-	if _, err := client.UpsertDashboard(ctx, folder, dashboard); err != nil {
-		return fmt.Errorf("could not apply dashboard: %w", err)
+	switch kind {
+	case decoder.KindPlaylist:
+		builder, err := decoder.UnmarshalPlaylistYAML(bytes.NewReader(content))
+		if err != nil {
+			return fmt.Errorf("could not decode input file '%s': %w", path, err)
+		}
+
+		sources.playlists = append(sources.playlists, playlistSource{path: path, playlist: builder})
+	default:
+		builder, err := decoder.UnmarshalYAML(bytes.NewReader(content))
+		if err != nil {
+			return fmt.Errorf("could not decode input file '%s': %w", path, err)
+		}
+
+		sources.dashboards = append(sources.dashboards, dashboardSource{path: path, folder: folder, subFolder: subFolder, dashboard: builder})
 	}
 
 	return nil
 }
 
-func grabanaClient(opts applyOpts) *grabana.Client {
+func isYAMLFile(path string) bool {
+	extension := strings.ToLower(filepath.Ext(path))
+
+	return extension == ".yaml" || extension == ".yml"
+}
+
+func sortedFolderTitles(folders map[string]*grabana.Folder) []string {
+	titles := make([]string, 0, len(folders))
+	for title := range folders {
+		titles = append(titles, title)
+	}
+
+	sort.Strings(titles)
+
+	return titles
+}
+
+func grabanaClient(host string, token string) *grabana.Client {
 	var clientOpts []grabana.Option
-	if len(opts.grafanaToken) != 0 {
-		clientOpts = append(clientOpts, grabana.WithAPIToken(opts.grafanaToken))
+	if len(token) != 0 {
+		clientOpts = append(clientOpts, grabana.WithAPIToken(token))
 	}
 
-	return grabana.NewClient(&http.Client{}, opts.grafanaHost, clientOpts...)
+	return grabana.NewClient(&http.Client{}, host, clientOpts...)
 }
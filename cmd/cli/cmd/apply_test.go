@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/K-Phoen/grabana"
+	"github.com/K-Phoen/grabana/dashboard"
+	"github.com/K-Phoen/grabana/grabanatest"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestPlaylistsCanBeAppliedAlongsideDashboards(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	input := t.TempDir()
+	writeFile(t, filepath.Join(input, "service.yaml"), "title: Service\nuid: service\n")
+	writeFile(t, filepath.Join(input, "noc.yaml"), "kind: playlist\nname: NOC\nitems:\n  - dashboard_uid: service\n")
+
+	sources, err := collectSources(input, "Team")
+	req.NoError(err)
+	req.Len(sources.dashboards, 1)
+	req.Len(sources.playlists, 1)
+
+	server := grabanatest.NewServer()
+	defer server.Close()
+
+	summary, err := applyAll(ctx, server.Client(), sources, applyOpts{})
+	req.NoError(err)
+	req.ElementsMatch([]string{"Team/Service", "playlist:NOC"}, summary.created)
+
+	req.Len(server.Dashboards(), 1)
+	req.Len(server.Playlists(), 1)
+
+	summary, err = applyAll(ctx, server.Client(), sources, applyOpts{})
+	req.NoError(err)
+	req.Equal([]string{"playlist:NOC"}, summary.updated)
+	req.Equal([]string{"Team/Service"}, summary.unchanged)
+	req.Len(server.Playlists(), 1)
+}
+
+func TestSubDirectoriesAreAppliedToNestedFolders(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	input := t.TempDir()
+	writeFile(t, filepath.Join(input, "overview.yaml"), "title: Overview\n")
+	writeFile(t, filepath.Join(input, "team", "databases", "postgres.yaml"), "title: Postgres\n")
+
+	sources, err := collectSources(input, "Platform")
+	req.NoError(err)
+
+	server := grabanatest.NewServer()
+	defer server.Close()
+
+	client := server.Client()
+
+	summary, err := applyAll(ctx, client, sources, applyOpts{})
+	req.NoError(err)
+	req.ElementsMatch([]string{"Platform/Overview", "Platform/team/databases/Postgres"}, summary.created)
+
+	databases, err := client.GetFolderByPath(ctx, "Platform/team/databases")
+	req.NoError(err)
+
+	dashboards, err := client.ListDashboardsInFolder(ctx, databases)
+	req.NoError(err)
+	req.Len(dashboards, 1)
+	req.Equal("Postgres", dashboards[0].Title)
+}
+
+func TestTheRootFolderIsFoundByItsExactTitle(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	input := t.TempDir()
+	writeFile(t, filepath.Join(input, "service.yaml"), "title: Service\n")
+	writeFile(t, filepath.Join(input, "databases", "postgres.yaml"), "title: Postgres\n")
+
+	sources, err := collectSources(input, "Team A/B")
+	req.NoError(err)
+
+	server := grabanatest.NewServer()
+	defer server.Close()
+
+	existing := server.AddFolder("Team A/B")
+	client := server.Client()
+
+	summary, err := applyAll(ctx, client, sources, applyOpts{})
+	req.NoError(err)
+	req.ElementsMatch([]string{"Team A/B/Service", "Team A/B/databases/Postgres"}, summary.created)
+
+	// no "Team A" folder with a "B" child was created
+	_, err = client.GetFolderByTitle(ctx, "Team A")
+	req.ErrorIs(err, grabana.ErrFolderNotFound)
+
+	dashboards, err := client.ListDashboardsInFolder(ctx, &existing)
+	req.NoError(err)
+	req.Len(dashboards, 1)
+	req.Equal("Service", dashboards[0].Title)
+
+	databases, err := client.GetChildFolderByPath(ctx, &existing, "databases")
+	req.NoError(err)
+
+	dashboards, err = client.ListDashboardsInFolder(ctx, databases)
+	req.NoError(err)
+	req.Len(dashboards, 1)
+	req.Equal("Postgres", dashboards[0].Title)
+}
+
+func TestPermissionsAreEnforcedOnUnchangedDashboards(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := grabanatest.NewServer()
+	defer server.Close()
+
+	client := server.Client()
+
+	builder, err := dashboard.New("Service",
+		dashboard.UID("service"),
+		dashboard.Permissions(dashboard.RolePermission("Viewer", dashboard.PermissionView)),
+	)
+	req.NoError(err)
+	sources := applySources{
+		dashboards: []dashboardSource{{path: "service.yaml", folder: "Team", dashboard: builder}},
+	}
+
+	_, err = applyAll(ctx, client, sources, applyOpts{})
+	req.NoError(err)
+
+	// permissions edited by hand
+	req.NoError(client.SetDashboardPermissions(ctx, "service", nil))
+
+	summary, err := applyAll(ctx, client, sources, applyOpts{})
+	req.NoError(err)
+	req.Equal([]string{"Team/Service"}, summary.unchanged)
+
+	req.Equal([]grabana.Permission{
+		{Role: "Viewer", Permission: dashboard.PermissionView},
+	}, server.DashboardPermissions("service"))
+}
+
+func TestPruneLeavesTheGeneralFolderAlone(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := grabanatest.NewServer()
+	defer server.Close()
+
+	// older Grafana versions ignore the folder filter of searches
+	server.IgnoreSearchFolderFilter()
+	client := server.Client()
+
+	general, err := dashboard.New("Unmanaged")
+	req.NoError(err)
+	_, err = client.UpsertDashboard(ctx, &grabana.Folder{}, general)
+	req.NoError(err)
+
+	input := t.TempDir()
+	writeFile(t, filepath.Join(input, "service.yaml"), "title: Service\n")
+
+	sources, err := collectSources(input, "Team")
+	req.NoError(err)
+
+	summary, err := applyAll(ctx, client, sources, applyOpts{prune: true})
+	req.NoError(err)
+	req.Empty(summary.deleted)
+
+	_, found := server.DashboardByTitle("Unmanaged")
+	req.True(found)
+}
+
+func TestFilesWithAnUnknownKindAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	input := t.TempDir()
+	writeFile(t, filepath.Join(input, "folder.yaml"), "kind: folder\ntitle: Team\n")
+
+	_, err := collectSources(input, "Team")
+
+	req.Error(err)
+	req.Contains(err.Error(), "folder.yaml")
+}
+
+func TestApplyingToOrganizationsRequiresBasicAuth(t *testing.T) {
+	req := require.New(t)
+
+	input := t.TempDir()
+	writeFile(t, filepath.Join(input, "service.yaml"), "title: Service\n")
+
+	err := applyYAML(applyOpts{
+		inputYAML:         input,
+		destinationFolder: "Team",
+		grafanaHost:       "http://localhost",
+		grafanaToken:      "token",
+		orgs:              []string{"Other"},
+	})
+
+	req.ErrorIs(err, ErrOrgsRequireBasicAuth)
+}
+
+func TestOrganizationsAreSwitchedWithBasicAuth(t *testing.T) {
+	req := require.New(t)
+
+	var user, password string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, _ = r.BasicAuth()
+		req.Equal("/api/orgs/name/Other", r.URL.Path)
+
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	input := t.TempDir()
+	writeFile(t, filepath.Join(input, "service.yaml"), "title: Service\n")
+
+	err := applyYAML(applyOpts{
+		inputYAML:         input,
+		destinationFolder: "Team",
+		grafanaHost:       ts.URL,
+		grafanaUser:       "admin",
+		grafanaPassword:   "secret",
+		orgs:              []string{"Other"},
+	})
+
+	req.ErrorIs(err, grabana.ErrOrgNotFound)
+	req.Equal("admin", user)
+	req.Equal("secret", password)
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/K-Phoen/grabana"
+	"github.com/K-Phoen/grabana/decoder"
+	"github.com/spf13/cobra"
+)
+
+// ErrDriftDetected is returned when the live dashboard differs from its YAML definition.
+var ErrDriftDetected = errors.New("drift detected")
+
+type diffOpts struct {
+	inputYAML         string
+	destinationFolder string
+	grafanaHost       string
+	grafanaToken      string
+}
+
+func Diff() *cobra.Command {
+	opts := diffOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show what applying a YAML dashboard would change",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return diffYAML(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.inputYAML, "input", "i", "", "YAML file used as input")
+	cmd.Flags().StringVarP(&opts.destinationFolder, "folder", "f", "", "Folder in which the dashboard would be created")
+	cmd.Flags().StringVarP(&opts.grafanaHost, "grafana", "g", "", "Grafana host. Example: http://grafana-host:3000")
+	cmd.Flags().StringVarP(&opts.grafanaToken, "token", "t", "", "Grafana API token")
+
+	_ = cmd.MarkFlagFilename("input", "yaml", "yml")
+
+	_ = cmd.MarkFlagRequired("input")
+	_ = cmd.MarkFlagRequired("folder")
+	_ = cmd.MarkFlagRequired("grafana")
+
+	return cmd
+}
+
+func diffYAML(opts diffOpts) error {
+	ctx := context.Background()
+	client := grabanaClient(opts.grafanaHost, opts.grafanaToken)
+
+	file, err := os.Open(opts.inputYAML)
+	if err != nil {
+		return fmt.Errorf("could not open input file '%s': %w", opts.inputYAML, err)
+	}
+
+	dashboard, err := decoder.UnmarshalYAML(file)
+	if err != nil {
+		return fmt.Errorf("could not decode input file '%s': %w", opts.inputYAML, err)
+	}
+
+	// the folder is resolved like apply does, but never created
+	source := dashboardSource{path: opts.inputYAML, folder: opts.destinationFolder, dashboard: dashboard}
+	folder, err := findFolder(ctx, client, source)
+	if err != nil && !errors.Is(err, grabana.ErrFolderNotFound) {
+		return fmt.Errorf("could not find folder '%s': %w", opts.destinationFolder, err)
+	}
+
+	diff, err := client.DiffDashboard(ctx, folder, dashboard)
+	if err != nil {
+		return fmt.Errorf("could not compute diff: %w", err)
+	}
+
+	fmt.Print(diff.String())
+
+	if diff.HasChanges() {
+		return ErrDriftDetected
+	}
+
+	return nil
+}
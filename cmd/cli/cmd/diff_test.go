@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/K-Phoen/grabana/grabanatest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffResolvesTheFolderLikeApply(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	input := t.TempDir()
+	path := filepath.Join(input, "service.yaml")
+	writeFile(t, path, "title: Service\n")
+
+	server := grabanatest.NewServer()
+	defer server.Close()
+
+	sources, err := collectSources(path, "Team A/B")
+	req.NoError(err)
+	_, err = applyAll(ctx, server.Client(), sources, applyOpts{})
+	req.NoError(err)
+
+	err = diffYAML(diffOpts{inputYAML: path, destinationFolder: "Team A/B", grafanaHost: server.URL})
+	req.NoError(err)
+}
+
+func TestDiffDoesNotCreateMissingFolders(t *testing.T) {
+	req := require.New(t)
+
+	input := t.TempDir()
+	path := filepath.Join(input, "service.yaml")
+	writeFile(t, path, "title: Service\n")
+
+	server := grabanatest.NewServer()
+	defer server.Close()
+
+	err := diffYAML(diffOpts{inputYAML: path, destinationFolder: "Team", grafanaHost: server.URL})
+
+	req.ErrorIs(err, ErrDriftDetected)
+	req.Empty(server.Folders())
+}
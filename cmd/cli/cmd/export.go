@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/K-Phoen/grabana"
+	"github.com/K-Phoen/grabana/decoder"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+type exportOpts struct {
+	uid          string
+	outputYAML   string
+	grafanaHost  string
+	grafanaToken string
+}
+
+func Export() *cobra.Command {
+	opts := exportOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export a Grafana dashboard as YAML",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return exportYAML(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.uid, "uid", "u", "", "UID of the dashboard to export")
+	cmd.Flags().StringVarP(&opts.outputYAML, "output", "o", "", "YAML file to write. Defaults to stdout")
+	cmd.Flags().StringVarP(&opts.grafanaHost, "grafana", "g", "", "Grafana host. Example: http://grafana-host:3000")
+	cmd.Flags().StringVarP(&opts.grafanaToken, "token", "t", "", "Grafana API token")
+
+	_ = cmd.MarkFlagFilename("output", "yaml", "yml")
+
+	_ = cmd.MarkFlagRequired("uid")
+	_ = cmd.MarkFlagRequired("grafana")
+
+	return cmd
+}
+
+func exportYAML(opts exportOpts) error {
+	ctx := context.Background()
+	client := grabanaClient(opts.grafanaHost, opts.grafanaToken)
+
+	var output io.Writer = os.Stdout
+	if opts.outputYAML != "" {
+		file, err := os.Create(opts.outputYAML)
+		if err != nil {
+			return fmt.Errorf("could not create output file '%s': %w", opts.outputYAML, err)
+		}
+		defer func() { _ = file.Close() }()
+
+		output = file
+	}
+
+	return exportDashboard(ctx, client, opts.uid, output)
+}
+
+func exportDashboard(ctx context.Context, client *grabana.Client, uid string, output io.Writer) error {
+	board, err := client.GetDashboardByUID(ctx, uid)
+	if err != nil {
+		return fmt.Errorf("could not fetch dashboard '%s': %w", uid, err)
+	}
+
+	// grabana references datasources by name, while Grafana stores their UID
+	datasources, err := client.ListDatasources(ctx)
+	if err != nil {
+		return fmt.Errorf("could not list datasources: %w", err)
+	}
+
+	datasourceNames := make(map[string]string, len(datasources))
+	for _, datasource := range datasources {
+		datasourceNames[datasource.UID] = datasource.Name
+	}
+
+	model, warnings := decoder.ExportBoard(board, datasourceNames)
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+	}
+
+	encoder := yaml.NewEncoder(output)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(model); err != nil {
+		return fmt.Errorf("could not encode dashboard '%s': %w", uid, err)
+	}
+
+	return encoder.Close()
+}
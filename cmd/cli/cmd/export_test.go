@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/K-Phoen/grabana/decoder"
+	"github.com/K-Phoen/grabana/grabanatest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportedDashboardsReferenceDatasourcesByName(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := grabanatest.NewServer()
+	defer server.Close()
+
+	datasource := server.AddDatasource("Prometheus", "prometheus", true)
+	folder := server.AddFolder("Team")
+	client := server.Client()
+
+	// dashboards saved by recent Grafana versions reference datasources by UID
+	payload := fmt.Sprintf(`{"dashboard": {
+	"uid": "service",
+	"title": "Service",
+	"panels": [{
+		"type": "timeseries",
+		"title": "Errors",
+		"datasource": {"type": "prometheus", "uid": %[1]q},
+		"targets": [{"refId": "A", "expr": "sum(rate(errors_total[5m]))", "datasource": {"type": "prometheus", "uid": %[1]q}}]
+	}]
+}}`, datasource.UID)
+	resp, err := http.Post(server.URL+"/api/dashboards/db", "application/json", strings.NewReader(payload))
+	req.NoError(err)
+	_ = resp.Body.Close()
+	req.Equal(http.StatusOK, resp.StatusCode)
+
+	output := &bytes.Buffer{}
+	req.NoError(exportDashboard(ctx, client, "service", output))
+
+	req.Contains(output.String(), "datasource: Prometheus")
+	req.NotContains(output.String(), datasource.UID)
+
+	// the exported dashboard can be applied again
+	builder, err := decoder.UnmarshalYAML(output)
+	req.NoError(err)
+
+	_, err = client.UpsertDashboard(ctx, &folder, builder)
+	req.NoError(err)
+}
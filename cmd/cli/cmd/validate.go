@@ -1,10 +1,6 @@
 package cmd
 
 import (
-	"fmt"
-	"os"
-
-	"github.com/K-Phoen/grabana/decoder"
 	"github.com/spf13/cobra"
 )
 
@@ -17,7 +13,7 @@ func Validate() *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:   "validate",
-		Short: "Validate a YAML dashboard",
+		Short: "Validate a YAML dashboard or playlist",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return validateYAML(opts)
 		},
@@ -32,14 +28,7 @@ func Validate() *cobra.Command {
 }
 
 func validateYAML(opts validateOpts) error {
-	file, err := os.Open(opts.inputYAML)
-	if err != nil {
-		return fmt.Errorf("could not open input file '%s': %w", opts.inputYAML, err)
-	}
-
-	if _, err := decoder.UnmarshalYAML(file); err != nil {
-		return fmt.Errorf("could not decode input file '%s': %w", opts.inputYAML, err)
-	}
+	sources := applySources{}
 
-	return nil
+	return sources.decode(opts.inputYAML, "", "")
 }
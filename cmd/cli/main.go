@@ -18,6 +18,8 @@ func main() {
 	root.AddCommand(cmd.Validate())
 	root.AddCommand(cmd.SelfUpdate(version))
 	root.AddCommand(cmd.Render())
+	root.AddCommand(cmd.Diff())
+	root.AddCommand(cmd.Export())
 
 	if err := root.Execute(); err != nil {
 		os.Exit(1)
@@ -0,0 +1,236 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/K-Phoen/grabana/alertmanager"
+	"github.com/K-Phoen/sdk"
+)
+
+// ErrContactPointNotFound is returned when the given contact point can not be found.
+var ErrContactPointNotFound = errors.New("contact point not found")
+
+// ContactPoint represents a single integration of a contact point, as
+// exposed by Grafana's provisioning API. A contact point with several
+// integrations is exposed as several ContactPoint sharing the same name.
+// See https://grafana.com/docs/grafana/latest/developers/http_api/alerting_provisioning/#contact-points
+type ContactPoint struct {
+	UID                   string                 `json:"uid,omitempty"`
+	Name                  string                 `json:"name"`
+	Type                  string                 `json:"type"`
+	Settings              map[string]interface{} `json:"settings"`
+	DisableResolveMessage bool                   `json:"disableResolveMessage"`
+}
+
+// ListContactPoints returns every contact point integration of the current
+// organization. Secure settings are redacted by Grafana.
+func (client *Client) ListContactPoints(ctx context.Context) ([]ContactPoint, error) {
+	return client.listContactPoints(ctx, url.Values{})
+}
+
+// GetContactPointsByName returns the integrations of the contact point
+// having the given name.
+func (client *Client) GetContactPointsByName(ctx context.Context, name string) ([]ContactPoint, error) {
+	contactPoints, err := client.listContactPoints(ctx, url.Values{"name": []string{name}})
+	if err != nil {
+		return nil, err
+	}
+
+	// older Grafana versions ignore the name filter
+	var matching []ContactPoint
+	for _, contactPoint := range contactPoints {
+		if contactPoint.Name == name {
+			matching = append(matching, contactPoint)
+		}
+	}
+	if len(matching) == 0 {
+		return nil, ErrContactPointNotFound
+	}
+
+	return matching, nil
+}
+
+// UpsertContactPoint creates or replaces a single contact point, leaving the
+// other ones untouched. Contact points are identified by their name: after
+// the upsert, the contact point has exactly the integrations defined by the
+// given Contact.
+func (client *Client) UpsertContactPoint(ctx context.Context, contact alertmanager.Contact) error {
+	existing, err := client.GetContactPointsByName(ctx, contact.Builder.Name)
+	if err != nil && !errors.Is(err, ErrContactPointNotFound) {
+		return err
+	}
+
+	unclaimed := map[string]ContactPoint{}
+	for _, contactPoint := range existing {
+		unclaimed[contactPoint.UID] = contactPoint
+	}
+
+	for _, integration := range contact.Builder.GrafanaManagedReceivers {
+		contactPoint := contactPointFromIntegration(contact.Builder.Name, integration)
+
+		// integrations without an explicit UID take over an existing one of
+		// the same type, so that they keep their identity across upserts.
+		if contactPoint.UID == "" {
+			for _, candidate := range existing {
+				if _, ok := unclaimed[candidate.UID]; ok && candidate.Type == contactPoint.Type {
+					contactPoint.UID = candidate.UID
+					break
+				}
+			}
+		}
+
+		exists := false
+		if contactPoint.UID != "" {
+			_, exists = unclaimed[contactPoint.UID]
+		}
+
+		if err := client.saveContactPoint(ctx, contactPoint, exists); err != nil {
+			return err
+		}
+
+		delete(unclaimed, contactPoint.UID)
+	}
+
+	for _, contactPoint := range existing {
+		if _, ok := unclaimed[contactPoint.UID]; !ok {
+			continue
+		}
+
+		if err := client.deleteContactPoint(ctx, contactPoint.UID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteContactPoint deletes every integration of the contact point having
+// the given name.
+func (client *Client) DeleteContactPoint(ctx context.Context, name string) error {
+	contactPoints, err := client.GetContactPointsByName(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	for _, contactPoint := range contactPoints {
+		if err := client.deleteContactPoint(ctx, contactPoint.UID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func contactPointFromIntegration(name string, integration sdk.ContactPointType) ContactPoint {
+	// the provisioning API expects secure settings along with the other ones
+	settings := make(map[string]interface{}, len(integration.Settings)+len(integration.SecureSettings))
+	for key, value := range integration.Settings {
+		settings[key] = value
+	}
+	for key, value := range integration.SecureSettings {
+		setNestedSetting(settings, strings.Split(key, "."), value)
+	}
+
+	return ContactPoint{
+		UID:                   integration.UID,
+		Name:                  name,
+		Type:                  integration.Type,
+		Settings:              settings,
+		DisableResolveMessage: integration.DisableResolveMessage,
+	}
+}
+
+// setNestedSetting sets a setting given its path: secure settings of some
+// contact point types are nested, such as "sigv4.access_key".
+func setNestedSetting(settings map[string]interface{}, path []string, value interface{}) {
+	if len(path) == 1 {
+		settings[path[0]] = value
+		return
+	}
+
+	// nested settings are copied, to leave the contact point's own untouched
+	nested := map[string]interface{}{}
+	if existing, ok := settings[path[0]].(map[string]interface{}); ok {
+		for key, value := range existing {
+			nested[key] = value
+		}
+	}
+
+	setNestedSetting(nested, path[1:], value)
+	settings[path[0]] = nested
+}
+
+func (client *Client) listContactPoints(ctx context.Context, query url.Values) ([]ContactPoint, error) {
+	path := "/api/v1/provisioning/contact-points"
+	if len(query) != 0 {
+		path += "?" + query.Encode()
+	}
+
+	resp, err := client.get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var contactPoints []ContactPoint
+	if err := decodeJSON(resp.Body, &contactPoints); err != nil {
+		return nil, err
+	}
+
+	return contactPoints, nil
+}
+
+func (client *Client) saveContactPoint(ctx context.Context, contactPoint ContactPoint, exists bool) error {
+	buf, err := json.Marshal(contactPoint)
+	if err != nil {
+		return err
+	}
+
+	method := http.MethodPost
+	path := "/api/v1/provisioning/contact-points"
+	if exists {
+		method = http.MethodPut
+		path += "/" + url.PathEscape(contactPoint.UID)
+	}
+
+	resp, err := client.sendJSON(ctx, method, path, buf)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
+
+func (client *Client) deleteContactPoint(ctx context.Context, uid string) error {
+	resp, err := client.delete(ctx, "/api/v1/provisioning/contact-points/"+url.PathEscape(uid))
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrContactPointNotFound
+	}
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
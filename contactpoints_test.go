@@ -0,0 +1,160 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/K-Phoen/grabana/alertmanager"
+	"github.com/K-Phoen/grabana/alertmanager/email"
+	"github.com/K-Phoen/grabana/alertmanager/slack"
+	"github.com/K-Phoen/grabana/alertmanager/sns"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetContactPointsByNameFiltersIntegrations(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/v1/provisioning/contact-points", r.URL.Path)
+		req.Equal("team-a", r.URL.Query().Get("name"))
+
+		_, _ = fmt.Fprintln(w, `[
+  {"uid": "cp-1", "name": "team-a", "type": "email", "settings": {"addresses": "team-a@example.com"}},
+  {"uid": "cp-2", "name": "team-b", "type": "email", "settings": {"addresses": "team-b@example.com"}}
+]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	contactPoints, err := client.GetContactPointsByName(context.TODO(), "team-a")
+
+	req.NoError(err)
+	req.Len(contactPoints, 1)
+	req.Equal("cp-1", contactPoints[0].UID)
+}
+
+func TestGetContactPointsByNameFailsForUnknownContactPoints(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.GetContactPointsByName(context.TODO(), "team-a")
+
+	req.ErrorIs(err, ErrContactPointNotFound)
+}
+
+func TestUpsertContactPointReconcilesIntegrations(t *testing.T) {
+	req := require.New(t)
+
+	var requests []string
+	var payloads []map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = fmt.Fprintln(w, `[
+  {"uid": "cp-email", "name": "team-a", "type": "email", "settings": {"addresses": "old@example.com"}},
+  {"uid": "cp-webhook", "name": "team-a", "type": "webhook", "settings": {"url": "http://old"}}
+]`)
+			return
+		}
+
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method != http.MethodDelete {
+			payload := map[string]interface{}{}
+			req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+			payloads = append(payloads, payload)
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = fmt.Fprintln(w, `{}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+	contact := alertmanager.ContactPoint("team-a",
+		email.To([]string{"team-a@example.com"}),
+		slack.Webhook("https://hooks.slack.com/secret"),
+	)
+
+	err := client.UpsertContactPoint(context.TODO(), contact)
+
+	req.NoError(err)
+	req.Equal([]string{
+		"PUT /api/v1/provisioning/contact-points/cp-email",
+		"POST /api/v1/provisioning/contact-points",
+		"DELETE /api/v1/provisioning/contact-points/cp-webhook",
+	}, requests)
+	req.Equal("team-a@example.com", payloads[0]["settings"].(map[string]interface{})["addresses"])
+	// secure settings are sent along with the other ones
+	req.Equal("https://hooks.slack.com/secret", payloads[1]["settings"].(map[string]interface{})["url"])
+	req.Equal("team-a", payloads[1]["name"])
+}
+
+func TestDeleteContactPointRemovesEveryIntegration(t *testing.T) {
+	req := require.New(t)
+
+	var deleted []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = fmt.Fprintln(w, `[{"uid": "cp-1", "name": "team-a", "type": "email"}, {"uid": "cp-2", "name": "team-a", "type": "slack"}]`)
+			return
+		}
+
+		deleted = append(deleted, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.DeleteContactPoint(context.TODO(), "team-a")
+
+	req.NoError(err)
+	req.Equal([]string{
+		"/api/v1/provisioning/contact-points/cp-1",
+		"/api/v1/provisioning/contact-points/cp-2",
+	}, deleted)
+}
+
+func TestUpsertContactPointNestsSecureSettings(t *testing.T) {
+	req := require.New(t)
+
+	var payload map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = fmt.Fprintln(w, `[]`)
+			return
+		}
+
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = fmt.Fprintln(w, `{}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+	contact := alertmanager.ContactPoint("team-a", alertmanager.Integration(
+		sns.Topic("arn:aws:sns:eu-west-1:123:alerts", sns.Region("eu-west-1"), sns.Credentials("access", "secret")),
+		alertmanager.DisableResolveMessage(),
+	))
+
+	err := client.UpsertContactPoint(context.TODO(), contact)
+
+	req.NoError(err)
+	req.Equal(true, payload["disableResolveMessage"])
+	req.Equal(map[string]interface{}{
+		"region":     "eu-west-1",
+		"access_key": "access",
+		"secret_key": "secret",
+	}, payload["settings"].(map[string]interface{})["sigv4"])
+
+	// the builder itself is left untouched
+	req.NotContains(contact.Builder.GrafanaManagedReceivers[0].Settings["sigv4"], "access_key")
+}
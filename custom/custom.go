@@ -46,6 +46,15 @@ func CustomConfig(config map[string]any) Option {
 	}
 }
 
+// Type sets the plugin type of this panel. Example: "grafana-piechart-panel".
+func Type(pluginType string) Option {
+	return func(cst *Custom) error {
+		cst.Builder.Type = pluginType
+
+		return nil
+	}
+}
+
 // Links adds links to be displayed on this panel.
 func Links(panelLinks ...links.Link) Option {
 	return func(cst *Custom) error {
@@ -106,7 +115,7 @@ func Alert(name string, opts ...alert.Option) Option {
 		obj := alert.New(name, opts...)
 
 		for i, data := range obj.Builder.Data {
-			if data.DatasourceUid == "" {
+			if data.DatasourceUid == "" && cst.Builder.Datasource != nil {
 				data.DatasourceUid = cst.Builder.Datasource.LegacyName
 				obj.Builder.Data[i] = data
 			}
@@ -48,6 +48,10 @@ const Browser TimezoneOption = "browser"
 type Builder struct {
 	board  *sdk.Board
 	Alerts []*alert.Alert
+
+	// Permissions replace the dashboard's own permissions when it is
+	// upserted. A nil value leaves them untouched.
+	Permissions []Permission
 }
 
 // New creates a new dashboard builder.
@@ -355,3 +359,14 @@ func Alert(name string, opts ...alert.Option) Option {
 		return nil
 	}
 }
+
+// Permissions defines the permissions that will be enforced on the dashboard.
+// They replace any permission set on the dashboard itself: calling it without
+// any permission only leaves the ones inherited from the folder.
+func Permissions(permissions ...Permission) Option {
+	return func(builder *Builder) error {
+		builder.Permissions = append([]Permission{}, permissions...)
+
+		return nil
+	}
+}
@@ -259,3 +259,39 @@ func TestDashboardCanHaveDashboardLinks(t *testing.T) {
 	req.NoError(err)
 	req.Len(panel.board.Links, 2)
 }
+
+func TestPermissionsAreNotManagedByDefault(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("")
+
+	req.NoError(err)
+	req.Nil(panel.Permissions)
+}
+
+func TestDashboardCanHavePermissions(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Permissions(
+		RolePermission("Viewer", PermissionView),
+		TeamPermission(2, PermissionEdit),
+		UserPermission(3, PermissionAdmin),
+	))
+
+	req.NoError(err)
+	req.Equal([]Permission{
+		{Role: "Viewer", Level: PermissionView},
+		{TeamID: 2, Level: PermissionEdit},
+		{UserID: 3, Level: PermissionAdmin},
+	}, panel.Permissions)
+}
+
+func TestDashboardPermissionsCanBeEmptied(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Permissions())
+
+	req.NoError(err)
+	req.NotNil(panel.Permissions)
+	req.Empty(panel.Permissions)
+}
@@ -0,0 +1,36 @@
+package dashboard
+
+// PermissionLevel describes the level of access granted by a permission.
+type PermissionLevel int
+
+const (
+	PermissionView  PermissionLevel = 1
+	PermissionEdit  PermissionLevel = 2
+	PermissionAdmin PermissionLevel = 4
+)
+
+// Permission grants a level of access on the dashboard to either a user, a
+// team or a built-in role ("Viewer" or "Editor").
+// See https://grafana.com/docs/grafana/latest/administration/roles-and-permissions/#dashboard-permissions
+type Permission struct {
+	UserID uint
+	TeamID uint
+	Role   string
+	Level  PermissionLevel
+}
+
+// UserPermission grants a level of access to a user.
+func UserPermission(userID uint, level PermissionLevel) Permission {
+	return Permission{UserID: userID, Level: level}
+}
+
+// TeamPermission grants a level of access to a team.
+func TeamPermission(teamID uint, level PermissionLevel) Permission {
+	return Permission{TeamID: teamID, Level: level}
+}
+
+// RolePermission grants a level of access to every user having the given
+// built-in role ("Viewer" or "Editor").
+func RolePermission(role string, level PermissionLevel) Permission {
+	return Permission{Role: role, Level: level}
+}
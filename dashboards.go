@@ -17,6 +17,18 @@ import (
 // ErrDashboardNotFound is returned when the given dashboard can not be found.
 var ErrDashboardNotFound = errors.New("dashboard not found")
 
+// ErrDashboardVersionMismatch is returned when a dashboard could not be saved
+// because it was changed since its version was last read.
+var ErrDashboardVersionMismatch = errors.New("dashboard has been changed by someone else")
+
+// ErrDashboardNameExists is returned when a dashboard could not be saved
+// because another dashboard with the same name exists in the folder.
+var ErrDashboardNameExists = errors.New("a dashboard with the same name already exists in the folder")
+
+// ErrDashboardEditedManually is returned when a dashboard could not be saved
+// because its latest version was not authored by a provisioning identity.
+var ErrDashboardEditedManually = errors.New("dashboard has been edited manually")
+
 // Dashboard represents a Grafana dashboard.
 type Dashboard struct {
 	ID          int      `json:"id"`
@@ -33,26 +45,11 @@ type Dashboard struct {
 
 // GetDashboardByTitle finds a dashboard, given its title.
 func (client *Client) GetDashboardByTitle(ctx context.Context, title string) (*Dashboard, error) {
-	resp, err := client.get(ctx, "/api/search?type=dash-db&query="+url.QueryEscape(title))
+	dashboards, err := client.SearchDashboards(ctx, SearchDashboardsQuery{Query: title})
 	if err != nil {
 		return nil, err
 	}
 
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, client.httpError(resp)
-	}
-
-	var dashboards []Dashboard
-	if err := decodeJSON(resp.Body, &dashboards); err != nil {
-		return nil, err
-	}
-
-	if len(dashboards) == 0 {
-		return nil, ErrDashboardNotFound
-	}
-
 	for i := range dashboards {
 		if strings.EqualFold(dashboards[i].Title, title) {
 			return &dashboards[i], nil
@@ -89,17 +86,101 @@ func (client *Client) GetDashboardByUID(ctx context.Context, uid string) (*sdk.B
 	return &response.Board, nil
 }
 
+// ListDashboardsInFolder lists the dashboards stored in the given folder.
+func (client *Client) ListDashboardsInFolder(ctx context.Context, folder *Folder) ([]Dashboard, error) {
+	dashboards, err := client.SearchDashboards(ctx, SearchDashboardsQuery{FolderUIDs: []string{folder.UID}})
+	if err != nil {
+		return nil, err
+	}
+
+	// older Grafana versions ignore the folderUIDs filter
+	inFolder := make([]Dashboard, 0, len(dashboards))
+	for _, dash := range dashboards {
+		if dash.FolderUID != folder.UID {
+			continue
+		}
+
+		inFolder = append(inFolder, dash)
+	}
+
+	return inFolder, nil
+}
+
+// UpsertOption represents an option that can be used to customize how a
+// dashboard is upserted.
+type UpsertOption func(opts *upsertOptions)
+
+type upsertOptions struct {
+	message string
+
+	expectedVersion      *int
+	optimistic           bool
+	refuseManualEdits    bool
+	provisioningIdentity []string
+}
+
+// CommitMessage attaches a message to the dashboard version created by the
+// upsert, e.g. the SHA of the commit being deployed. It shows up in the
+// dashboard's version history.
+func CommitMessage(message string) UpsertOption {
+	return func(opts *upsertOptions) {
+		opts.message = message
+	}
+}
+
+// ExpectedVersion only saves the dashboard if its version in Grafana is the
+// given one. Otherwise, ErrDashboardVersionMismatch is returned.
+func ExpectedVersion(version int) UpsertOption {
+	return func(opts *upsertOptions) {
+		opts.expectedVersion = &version
+	}
+}
+
+// OptimisticConcurrency only saves the dashboard if it was not changed
+// between the moment it is read by the upsert and the moment it is written.
+// Concurrent upserts of the same dashboard fail with
+// ErrDashboardVersionMismatch instead of silently overwriting each other.
+func OptimisticConcurrency() UpsertOption {
+	return func(opts *upsertOptions) {
+		opts.optimistic = true
+	}
+}
+
+// RefuseManualEdits prevents the upsert from overwriting a dashboard whose
+// latest version was saved by someone else than the given logins (typically
+// the user or service account used for provisioning). If no login is given,
+// the identity of the client is used. ErrDashboardEditedManually is returned
+// when the dashboard was edited manually.
+func RefuseManualEdits(provisioningLogins ...string) UpsertOption {
+	return func(opts *upsertOptions) {
+		opts.refuseManualEdits = true
+		opts.provisioningIdentity = provisioningLogins
+	}
+}
+
 // UpsertDashboard creates or replaces a dashboard, in the given folder.
-func (client *Client) UpsertDashboard(ctx context.Context, folder *Folder, builder dashboard.Builder) (*Dashboard, error) {
+func (client *Client) UpsertDashboard(ctx context.Context, folder *Folder, builder dashboard.Builder, options ...UpsertOption) (*Dashboard, error) {
+	opts := upsertOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
 	// optionally search for the dashboard by title to get its ID
-	dashboardId, err := client.searchDashboardByTitle(ctx, folder, builder.Internal().Title)
+	existing, err := client.searchDashboardByTitle(ctx, folder, builder.Internal().Title)
 	if err != nil {
 		return nil, err
 	}
-	builder.Internal().ID = dashboardId
+	builder.Internal().ID = 0
+	if existing != nil {
+		builder.Internal().ID = uint(existing.ID)
+	}
+
+	if err := client.checkUpsertPreconditions(ctx, existing, builder, &opts); err != nil {
+		return nil, err
+	}
 
 	// first pass: save the new dashboard
-	dashboardModel, err := client.persistDashboard(ctx, folder, builder)
+	dashboardModel, err := client.persistDashboard(ctx, folder, builder, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -159,59 +240,148 @@ func (client *Client) UpsertDashboard(ctx context.Context, folder *Folder, build
 		}
 	}
 
+	// fourth pass: enforce permissions, if the builder manages them
+	if err := client.EnforceDashboardPermissions(ctx, dashboardModel.UID, builder); err != nil {
+		return nil, fmt.Errorf("could not set permissions for dashboard: %w", err)
+	}
+
 	return dashboardModel, nil
 }
 
-func (client *Client) searchDashboardByTitle(ctx context.Context, folder *Folder, title string) (uint, error) {
-	resp, err := client.get(ctx, "/api/search?folderUIDs="+url.QueryEscape(folder.UID)+"&type=dash-db&query="+url.QueryEscape(title))
+// UpsertDashboardInFolderPath creates or replaces a dashboard, in the folder
+// designated by the given path. Missing folders are created.
+// See EnsureFolderPath.
+func (client *Client) UpsertDashboardInFolderPath(ctx context.Context, folderPath string, builder dashboard.Builder, options ...UpsertOption) (*Dashboard, error) {
+	folder, err := client.EnsureFolderPath(ctx, folderPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not find or create folder '%s': %w", folderPath, err)
+	}
+
+	return client.UpsertDashboard(ctx, folder, builder, options...)
+}
+
+func (client *Client) checkUpsertPreconditions(ctx context.Context, existing *Dashboard, builder dashboard.Builder, opts *upsertOptions) error {
+	if opts.optimistic && opts.expectedVersion == nil {
+		// a dashboard that does not exist yet must still not exist when we save it
+		version := 0
+		if existing != nil {
+			live, err := client.GetDashboardByUID(ctx, existing.UID)
+			if err != nil {
+				return err
+			}
+
+			version = int(live.Version)
+		}
+
+		opts.expectedVersion = &version
+	}
+
+	if opts.expectedVersion != nil {
+		builder.Internal().Version = uint(*opts.expectedVersion)
+	}
+
+	if existing == nil || !opts.refuseManualEdits {
+		return nil
+	}
+
+	logins := opts.provisioningIdentity
+	if len(logins) == 0 {
+		login, err := client.currentLogin(ctx)
+		if err != nil {
+			return fmt.Errorf("could not determine provisioning identity: %w", err)
+		}
+
+		logins = []string{login}
+	}
+
+	versions, err := client.ListDashboardVersions(ctx, existing.UID)
 	if err != nil {
-		return 0, err
+		return err
+	}
+	if len(versions) == 0 {
+		return nil
+	}
+
+	latest := versions[0]
+	for _, login := range logins {
+		if latest.CreatedBy == login {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: version %d of dashboard '%s' was saved by '%s'", ErrDashboardEditedManually, latest.Version, existing.Title, latest.CreatedBy)
+}
+
+func (client *Client) currentLogin(ctx context.Context) (string, error) {
+	resp, err := client.get(ctx, "/api/user")
+	if err != nil {
+		return "", err
 	}
 
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return 0, client.httpError(resp)
+		return "", client.httpError(resp)
 	}
 
-	var data []struct {
-		ID    uint   `json:"id"`
-		UID   string `json:"uid"`
-		Title string `json:"title"`
+	user := struct {
+		Login string `json:"login"`
+	}{}
+	if err := decodeJSON(resp.Body, &user); err != nil {
+		return "", err
 	}
-	if err := decodeJSON(resp.Body, &data); err != nil {
-		return 0, err
+
+	return user.Login, nil
+}
+
+func (client *Client) searchDashboardByTitle(ctx context.Context, folder *Folder, title string) (*Dashboard, error) {
+	dashboards, err := client.SearchDashboards(ctx, SearchDashboardsQuery{Query: title, FolderUIDs: []string{folder.UID}})
+	if err != nil {
+		return nil, err
 	}
-	for _, d := range data {
-		if d.Title == title {
-			return d.ID, nil
+
+	for i := range dashboards {
+		if dashboards[i].Title == title {
+			return &dashboards[i], nil
 		}
 	}
 
-	return 0, nil
+	return nil, nil
 }
 
-func (client *Client) persistDashboard(ctx context.Context, folder *Folder, builder dashboard.Builder) (*Dashboard, error) {
+func (client *Client) persistDashboard(ctx context.Context, folder *Folder, builder dashboard.Builder, opts upsertOptions) (*Dashboard, error) {
 	buf, err := json.Marshal(struct {
 		Dashboard *sdk.Board `json:"dashboard"`
 		FolderUID string     `json:"folderUid"`
 		Overwrite bool       `json:"overwrite"`
+		Message   string     `json:"message,omitempty"`
 	}{
 		Dashboard: builder.Internal(),
 		FolderUID: folder.UID,
-		Overwrite: true,
+		Overwrite: opts.expectedVersion == nil,
+		Message:   opts.message,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := client.sendJSON(ctx, http.MethodPost, "/api/dashboards/db", buf)
+	// overwriting the dashboard yields the same result when done twice, so
+	// only those requests are retried
+	send := client.sendJSON
+	if opts.expectedVersion == nil {
+		send = client.sendRetryableJSON
+	}
+
+	resp, err := send(ctx, http.MethodPost, "/api/dashboards/db", buf)
 	if err != nil {
 		return nil, err
 	}
 
 	defer func() { _ = resp.Body.Close() }()
 
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return nil, preconditionError(client.httpError(resp))
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, client.httpError(resp)
 	}
@@ -224,6 +394,41 @@ func (client *Client) persistDashboard(ctx context.Context, folder *Folder, buil
 	return &model, nil
 }
 
+// dashboardConflictError ties the sentinel describing why Grafana refused to
+// save a dashboard to the underlying API error.
+type dashboardConflictError struct {
+	reason error
+	apiErr error
+}
+
+func (err *dashboardConflictError) Error() string {
+	return fmt.Sprintf("%s: %s", err.reason, err.apiErr)
+}
+
+func (err *dashboardConflictError) Is(target error) bool {
+	return target == err.reason
+}
+
+func (err *dashboardConflictError) Unwrap() error {
+	return err.apiErr
+}
+
+func preconditionError(err error) error {
+	apiErr := &APIError{}
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+
+	switch apiErr.Status {
+	case "version-mismatch":
+		return &dashboardConflictError{reason: ErrDashboardVersionMismatch, apiErr: err}
+	case "name-exists":
+		return &dashboardConflictError{reason: ErrDashboardNameExists, apiErr: err}
+	}
+
+	return err
+}
+
 // DeleteDashboard deletes a dashboard given its UID.
 func (client *Client) DeleteDashboard(ctx context.Context, uid string) error {
 	// first: delete existing alerts associated to that dashboard
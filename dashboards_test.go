@@ -2,12 +2,14 @@ package grabana
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/K-Phoen/grabana/dashboard"
 	"github.com/K-Phoen/sdk"
 	"github.com/stretchr/testify/require"
 )
@@ -137,6 +139,28 @@ func TestFetchingAnUnknownDashboardByUIDFailsCleanly(t *testing.T) {
 	req.ErrorIs(err, ErrDashboardNotFound)
 }
 
+func TestDashboardsCanBeListedByFolder(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("folder-uid", r.URL.Query().Get("folderUIDs"))
+
+		_, _ = fmt.Fprintln(w, `[
+  {"id": 1, "uid": "in-folder", "title": "In folder", "folderUid": "folder-uid"},
+  {"id": 2, "uid": "elsewhere", "title": "Elsewhere", "folderUid": "other-uid"},
+  {"id": 3, "uid": "general", "title": "General"}
+]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	dashboards, err := client.ListDashboardsInFolder(context.TODO(), &Folder{UID: "folder-uid"})
+
+	req.NoError(err)
+	req.Len(dashboards, 1)
+	req.Equal("in-folder", dashboards[0].UID)
+}
+
 //func TestDeleteDashboardWithNoAlerts(t *testing.T) {
 //	req := require.New(t)
 //	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -941,6 +965,63 @@ func TestFetchingAnUnknownDashboardByUIDFailsCleanly(t *testing.T) {
 //	req.True(newAlertCreated)
 //}
 
+func TestDashboardsArePersistedWithTheExpectedVersion(t *testing.T) {
+	req := require.New(t)
+
+	var payload map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+
+		_, _ = fmt.Fprintln(w, `{"id": 1, "uid": "dash-uid", "status": "success", "version": 4}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+	builder, err := dashboard.New("Test dashboard")
+	req.NoError(err)
+	builder.Internal().Version = 3
+
+	version := 3
+	_, err = client.persistDashboard(context.TODO(), &Folder{UID: "folder-uid"}, builder, upsertOptions{expectedVersion: &version})
+
+	req.NoError(err)
+	req.Equal(false, payload["overwrite"])
+	req.Equal(float64(3), payload["dashboard"].(map[string]interface{})["version"])
+}
+
+func TestVersionMismatchesAreReportedWhenPersistingDashboards(t *testing.T) {
+	testCases := []struct {
+		status   string
+		expected error
+	}{
+		{status: "version-mismatch", expected: ErrDashboardVersionMismatch},
+		{status: "name-exists", expected: ErrDashboardNameExists},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+
+		t.Run(tc.status, func(t *testing.T) {
+			req := require.New(t)
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				_, _ = fmt.Fprintf(w, `{"message": "nope", "status": "%s"}`, tc.status)
+			}))
+			defer ts.Close()
+
+			client := NewClient(http.DefaultClient, ts.URL)
+			builder, err := dashboard.New("Test dashboard")
+			req.NoError(err)
+
+			version := 3
+			_, err = client.persistDashboard(context.TODO(), &Folder{UID: "folder-uid"}, builder, upsertOptions{expectedVersion: &version})
+
+			req.ErrorIs(err, tc.expected)
+			req.True(IsPreconditionFailed(err))
+		})
+	}
+}
+
 func TestClient_panelIDByTitle_panelInBoard(t *testing.T) {
 	req := require.New(t)
 
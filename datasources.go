@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/K-Phoen/grabana/datasource"
 )
@@ -13,8 +14,48 @@ import (
 // ErrDatasourceNotFound is returned when the given datasource can not be found.
 var ErrDatasourceNotFound = errors.New("datasource not found")
 
+// ErrHealthCheckNotSupported is returned when the plugin of a datasource does
+// not implement health checks.
+var ErrHealthCheckNotSupported = errors.New("datasource health check not supported")
+
 const defaultDatasourceKey = "$grabana_default_datasource_key$"
 
+// DatasourceHealthOK is the status reported by healthy datasources.
+const DatasourceHealthOK = "OK"
+
+// DatasourceHealthError is the status reported by unhealthy datasources.
+const DatasourceHealthError = "ERROR"
+
+// Datasource represents a datasource, as defined in Grafana.
+// See https://grafana.com/docs/grafana/latest/datasources/
+type Datasource struct {
+	ID        uint   `json:"id"`
+	UID       string `json:"uid"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	URL       string `json:"url"`
+	Access    string `json:"access"`
+	Database  string `json:"database"`
+	User      string `json:"user"`
+	BasicAuth bool   `json:"basicAuth"`
+	IsDefault bool   `json:"isDefault"`
+	ReadOnly  bool   `json:"readOnly"`
+	// JSONData holds the type-specific settings of the datasource.
+	JSONData map[string]interface{} `json:"jsonData"`
+}
+
+// DatasourceHealth is the result of a datasource health check.
+type DatasourceHealth struct {
+	// Status is either DatasourceHealthOK or DatasourceHealthError.
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// Healthy tells if the health check succeeded.
+func (health DatasourceHealth) Healthy() bool {
+	return health.Status == DatasourceHealthOK
+}
+
 // UpsertDatasource creates or replaces a datasource.
 func (client *Client) UpsertDatasource(ctx context.Context, datasource datasource.Datasource) error {
 	buf, err := json.Marshal(datasource)
@@ -99,6 +140,88 @@ func (client *Client) GetDatasourceUIDByName(ctx context.Context, name string) (
 	return response.UID, nil
 }
 
+// ListDatasources returns the datasources of the current organization.
+func (client *Client) ListDatasources(ctx context.Context) ([]Datasource, error) {
+	resp, err := client.get(ctx, "/api/datasources")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var datasources []Datasource
+	if err := decodeJSON(resp.Body, &datasources); err != nil {
+		return nil, err
+	}
+
+	return datasources, nil
+}
+
+// GetDatasourceByUID finds a datasource, given its UID.
+func (client *Client) GetDatasourceByUID(ctx context.Context, uid string) (*Datasource, error) {
+	resp, err := client.get(ctx, "/api/datasources/uid/"+url.PathEscape(uid))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrDatasourceNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	datasource := &Datasource{}
+	if err := decodeJSON(resp.Body, datasource); err != nil {
+		return nil, err
+	}
+
+	return datasource, nil
+}
+
+// CheckDatasourceHealth asks Grafana to test the connection to a datasource,
+// given its UID. A failing check is reported through the returned
+// DatasourceHealth, not as an error. ErrHealthCheckNotSupported is returned
+// for datasources whose plugin does not implement health checks.
+func (client *Client) CheckDatasourceHealth(ctx context.Context, uid string) (*DatasourceHealth, error) {
+	resp, err := client.get(ctx, "/api/datasources/uid/"+url.PathEscape(uid)+"/health")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	// Grafana answers with a 404 both for unknown datasources and for plugins
+	// without health checks.
+	if resp.StatusCode == http.StatusNotFound {
+		if _, err := client.GetDatasourceByUID(ctx, uid); err != nil {
+			return nil, err
+		}
+
+		return nil, ErrHealthCheckNotSupported
+	}
+	// failed checks are reported with a 400 status code
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusBadRequest {
+		return nil, client.httpError(resp)
+	}
+
+	health := &DatasourceHealth{}
+	if err := decodeJSON(resp.Body, health); err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusBadRequest && health.Status == "" {
+		health.Status = DatasourceHealthError
+	}
+
+	return health, nil
+}
+
 // datasourcesUIDMap builds a map of datasources UIDs indexed by their name.
 func (client *Client) datasourcesUIDMap(ctx context.Context) (map[string]string, error) {
 	resp, err := client.get(ctx, "/api/datasources")
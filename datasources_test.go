@@ -218,3 +218,157 @@ func TestGetDatasourceUIDByNameReturnsASpecificErrorIfDatasourceIsNotFound(t *te
 	req.Equal(ErrDatasourceNotFound, err)
 	req.Empty(uid)
 }
+
+func TestListDatasources(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/datasources", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `[
+  {"id": 1, "uid": "prom-uid", "name": "Prometheus", "type": "prometheus", "url": "http://prometheus:9090", "isDefault": true},
+  {"id": 2, "uid": "loki-uid", "name": "Loki", "type": "loki", "jsonData": {"maxLines": 1000}}
+]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	datasources, err := client.ListDatasources(context.TODO())
+
+	req.NoError(err)
+	req.Len(datasources, 2)
+	req.Equal("prometheus", datasources[0].Type)
+	req.True(datasources[0].IsDefault)
+	req.Equal("http://prometheus:9090", datasources[0].URL)
+	req.Equal(float64(1000), datasources[1].JSONData["maxLines"])
+}
+
+func TestGetDatasourceByUID(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/datasources/uid/prom-uid", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `{"id": 1, "uid": "prom-uid", "name": "Prometheus", "type": "prometheus", "access": "proxy"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	datasource, err := client.GetDatasourceByUID(context.TODO(), "prom-uid")
+
+	req.NoError(err)
+	req.Equal("Prometheus", datasource.Name)
+	req.Equal("proxy", datasource.Access)
+}
+
+func TestGetDatasourceByUIDReturnsASpecificErrorIfDatasourceIsNotFound(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.GetDatasourceByUID(context.TODO(), "unknown")
+
+	req.ErrorIs(err, ErrDatasourceNotFound)
+}
+
+func TestCheckDatasourceHealth(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/datasources/uid/prom-uid/health", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `{"status": "OK", "message": "Successfully queried the Prometheus API."}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	health, err := client.CheckDatasourceHealth(context.TODO(), "prom-uid")
+
+	req.NoError(err)
+	req.True(health.Healthy())
+	req.Equal("Successfully queried the Prometheus API.", health.Message)
+}
+
+func TestCheckDatasourceHealthReportsFailedChecks(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprintln(w, `{"status": "ERROR", "message": "connection refused"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	health, err := client.CheckDatasourceHealth(context.TODO(), "prom-uid")
+
+	req.NoError(err)
+	req.False(health.Healthy())
+	req.Equal(DatasourceHealthError, health.Status)
+	req.Equal("connection refused", health.Message)
+}
+
+func TestCheckDatasourceHealthReportsUnknownDatasources(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprintln(w, `{"message": "Data source not found"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.CheckDatasourceHealth(context.TODO(), "prom-uid")
+
+	req.ErrorIs(err, ErrDatasourceNotFound)
+}
+
+func TestCheckDatasourceHealthReportsUnsupportedChecks(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/datasources/uid/csv-uid/health":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprintln(w, `{"message": "Health check not implemented"}`)
+		case "/api/datasources/uid/csv-uid":
+			_, _ = fmt.Fprintln(w, `{"id": 1, "uid": "csv-uid", "name": "CSV", "type": "marcusolsson-csv-datasource"}`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.CheckDatasourceHealth(context.TODO(), "csv-uid")
+
+	req.ErrorIs(err, ErrHealthCheckNotSupported)
+	req.False(IsNotFound(err))
+}
+
+func TestCheckDatasourceHealthForwardsErrorsOnFailure(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = fmt.Fprintln(w, `{"message": "Permission denied"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.CheckDatasourceHealth(context.TODO(), "prom-uid")
+
+	req.Error(err)
+	req.True(IsForbidden(err))
+}
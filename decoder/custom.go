@@ -0,0 +1,55 @@
+package decoder
+
+import (
+	"github.com/K-Phoen/grabana/custom"
+	"github.com/K-Phoen/grabana/row"
+)
+
+type DashboardCustom struct {
+	Title       string
+	Type        string              `yaml:",omitempty"`
+	Description string              `yaml:",omitempty"`
+	Span        float32             `yaml:",omitempty"`
+	Height      string              `yaml:",omitempty"`
+	Datasource  string              `yaml:",omitempty"`
+	Links       DashboardPanelLinks `yaml:",omitempty"`
+	Config      map[string]any      `yaml:",omitempty"`
+	Alerts      []UnifiedAlert      `yaml:",omitempty"`
+}
+
+func (customPanel DashboardCustom) toOption() (row.Option, error) {
+	opts := []custom.Option{}
+
+	if customPanel.Type != "" {
+		opts = append(opts, custom.Type(customPanel.Type))
+	}
+	if customPanel.Description != "" {
+		opts = append(opts, custom.Description(customPanel.Description))
+	}
+	if customPanel.Span != 0 {
+		opts = append(opts, custom.Span(customPanel.Span))
+	}
+	if customPanel.Height != "" {
+		opts = append(opts, custom.Height(customPanel.Height))
+	}
+	if customPanel.Datasource != "" {
+		opts = append(opts, custom.DataSource(customPanel.Datasource))
+	}
+	if len(customPanel.Links) != 0 {
+		opts = append(opts, custom.Links(customPanel.Links.toModel()...))
+	}
+	if len(customPanel.Config) != 0 {
+		opts = append(opts, custom.CustomConfig(customPanel.Config))
+	}
+
+	for _, a := range customPanel.Alerts {
+		alertOpts, err := a.toOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, custom.Alert(a.Title, alertOpts...))
+	}
+
+	return row.WithCustom(customPanel.Title, opts...), nil
+}
@@ -11,6 +11,7 @@ var ErrPanelNotConfigured = fmt.Errorf("panel not configured")
 var ErrInvalidTimezone = fmt.Errorf("invalid timezone")
 
 type DashboardModel struct {
+	Kind            Kind `yaml:",omitempty"`
 	Title           string
 	Slug            string `yaml:",omitempty"`
 	UID             string `yaml:"uid,omitempty"`
@@ -28,6 +29,8 @@ type DashboardModel struct {
 	DashboardLinks []DashboardInternalLink   `yaml:"dashboard_links,omitempty"`
 
 	Rows []DashboardRow
+
+	Alerts []UnifiedAlert `yaml:",omitempty"`
 }
 
 func (d *DashboardModel) ToBuilder() (dashboard.Builder, error) {
@@ -99,6 +102,15 @@ func (d *DashboardModel) ToBuilder() (dashboard.Builder, error) {
 		opts = append(opts, opt)
 	}
 
+	for _, a := range d.Alerts {
+		alertOpts, err := a.toOptions()
+		if err != nil {
+			return emptyDashboard, err
+		}
+
+		opts = append(opts, dashboard.Alert(a.Title, alertOpts...))
+	}
+
 	return dashboard.New(d.Title, opts...)
 }
 
@@ -128,6 +140,9 @@ type DashboardPanel struct {
 	TimeSeries *DashboardTimeSeries `yaml:"timeseries,omitempty"`
 	Logs       *DashboardLogs       `yaml:"logs,omitempty"`
 	Gauge      *DashboardGauge      `yaml:"gauge,omitempty"`
+	Custom     *DashboardCustom     `yaml:"custom,omitempty"`
+
+	LibraryPanel *DashboardLibraryPanel `yaml:"library_panel,omitempty"`
 }
 
 func (panel DashboardPanel) toOption() (row.Option, error) {
@@ -158,6 +173,12 @@ func (panel DashboardPanel) toOption() (row.Option, error) {
 	if panel.Gauge != nil {
 		return panel.Gauge.toOption()
 	}
+	if panel.Custom != nil {
+		return panel.Custom.toOption()
+	}
+	if panel.LibraryPanel != nil {
+		return panel.LibraryPanel.toOption(), nil
+	}
 
 	return nil, ErrPanelNotConfigured
 }
@@ -41,6 +41,7 @@ func TestUnmarshalYAML(t *testing.T) {
 		logsPanel(),
 		statPanel(),
 		gaugePanel(),
+		libraryPanel(),
 	}
 
 	for _, testCase := range testCases {
@@ -675,6 +676,26 @@ rows:
 	}
 }
 
+func libraryPanel() testCase {
+	yaml := `title: Awesome dashboard
+
+rows:
+  - name: SLO
+    panels:
+      - library_panel:
+          uid: slo-burn-rate
+          name: SLO burn rate
+          span: 12
+          height: 400px
+`
+
+	return testCase{
+		name:                "single row with one library panel",
+		yaml:                yaml,
+		expectedGrafanaJSON: "library_panel.json",
+	}
+}
+
 func tablePanel() testCase {
 	yaml := `title: Awesome dashboard
 
@@ -0,0 +1,777 @@
+package decoder
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/K-Phoen/grabana/dashboard"
+	"github.com/K-Phoen/sdk"
+)
+
+// ExportBoard converts a dashboard fetched from Grafana into a DashboardModel
+// that can be marshalled to YAML and decoded back by UnmarshalYAML.
+//
+// Features that grabana does not model are either approximated or dropped:
+// each of them is described by a warning. The optional datasourceNames map
+// (datasource names indexed by UID) is used to translate datasources
+// referenced by UID into the names expected by grabana.
+func ExportBoard(board *sdk.Board, datasourceNames map[string]string) (*DashboardModel, []string) {
+	exporter := &boardExporter{datasourceNames: datasourceNames, seenUIDs: map[string]bool{}}
+
+	return exporter.export(board), exporter.warnings
+}
+
+type boardExporter struct {
+	datasourceNames map[string]string
+	seenUIDs        map[string]bool
+	warnings        []string
+}
+
+func (exporter *boardExporter) warn(format string, args ...any) {
+	exporter.warnings = append(exporter.warnings, fmt.Sprintf(format, args...))
+}
+
+func (exporter *boardExporter) export(board *sdk.Board) *DashboardModel {
+	model := &DashboardModel{
+		Title:           board.Title,
+		UID:             board.UID,
+		Editable:        board.Editable,
+		SharedCrosshair: board.SharedCrosshair || board.GraphTooltip == 1,
+		Tags:            board.Tags,
+		Time:            [2]string{board.Time.From, board.Time.To},
+	}
+
+	if board.Refresh != nil && board.Refresh.Flag {
+		model.AutoRefresh = board.Refresh.Value
+	}
+
+	switch board.Timezone {
+	case "", "utc", "browser":
+		model.Timezone = board.Timezone
+	default:
+		exporter.warn("dashboard timezone %q is not supported and was dropped", board.Timezone)
+	}
+
+	exporter.exportAnnotations(board, model)
+	exporter.exportLinks(board, model)
+
+	for _, variable := range board.Templating.List {
+		if exported := exporter.exportVariable(variable); exported != nil {
+			model.Variables = append(model.Variables, *exported)
+		}
+	}
+
+	for _, r := range board.Rows {
+		model.Rows = append(model.Rows, exporter.exportLegacyRow(r))
+	}
+	model.Rows = append(model.Rows, exporter.exportPanelsLayout(board.Panels)...)
+
+	return model
+}
+
+func (exporter *boardExporter) exportAnnotations(board *sdk.Board, model *DashboardModel) {
+	for _, annotation := range board.Annotations.List {
+		if annotation.Type == "dashboard" {
+			// built-in "Annotations & Alerts" annotation, always present
+			continue
+		}
+		if annotation.Type != "tags" {
+			exporter.warn("annotation %q of type %q is not supported and was dropped", annotation.Name, annotation.Type)
+			continue
+		}
+
+		model.TagsAnnotation = append(model.TagsAnnotation, dashboard.TagAnnotation{
+			Name:       annotation.Name,
+			Datasource: exporter.datasourceName(annotation.Datasource),
+			IconColor:  annotation.IconColor,
+			Tags:       annotation.Tags,
+		})
+	}
+}
+
+func (exporter *boardExporter) exportLinks(board *sdk.Board, model *DashboardModel) {
+	boolValue := func(input *bool) bool { return input != nil && *input }
+
+	for _, link := range board.Links {
+		switch link.Type {
+		case "link":
+			model.ExternalLinks = append(model.ExternalLinks, DashboardExternalLink{
+				Title:                 link.Title,
+				URL:                   stringValue(link.URL),
+				Description:           stringValue(link.Tooltip),
+				Icon:                  stringValue(link.Icon),
+				IncludeTimeRange:      boolValue(link.KeepTime),
+				IncludeVariableValues: link.IncludeVars,
+				OpenInNewTab:          boolValue(link.TargetBlank),
+			})
+		case "dashboards":
+			model.DashboardLinks = append(model.DashboardLinks, DashboardInternalLink{
+				Title:                 link.Title,
+				Tags:                  link.Tags,
+				AsDropdown:            boolValue(link.AsDropdown),
+				IncludeTimeRange:      boolValue(link.KeepTime),
+				IncludeVariableValues: link.IncludeVars,
+				OpenInNewTab:          boolValue(link.TargetBlank),
+			})
+		default:
+			exporter.warn("dashboard link %q of type %q is not supported and was dropped", link.Title, link.Type)
+		}
+	}
+}
+
+func (exporter *boardExporter) exportVariable(variable sdk.TemplateVar) *DashboardVariable {
+	hide := ""
+	switch variable.Hide {
+	case 1:
+		hide = "label"
+	case 2:
+		hide = "variable"
+	}
+
+	valuesMap := func() map[string]string {
+		values := make(map[string]string, len(variable.Options))
+		for _, option := range variable.Options {
+			values[option.Text] = option.Value
+		}
+
+		return values
+	}
+
+	switch variable.Type {
+	case "query":
+		return &DashboardVariable{Query: &VariableQuery{
+			Name:       variable.Name,
+			Label:      variable.Label,
+			Datasource: exporter.datasourceName(variable.Datasource),
+			Request:    variableQueryString(variable.Query),
+			Regex:      variable.Regex,
+			IncludeAll: variable.IncludeAll,
+			AllValue:   variable.AllValue,
+			Hide:       hide,
+			Multiple:   variable.Multi,
+		}}
+	case "custom":
+		return &DashboardVariable{Custom: &VariableCustom{
+			Name:       variable.Name,
+			Label:      variable.Label,
+			Default:    currentValue(variable.Current),
+			ValuesMap:  valuesMap(),
+			IncludeAll: variable.IncludeAll,
+			AllValue:   variable.AllValue,
+			Hide:       hide,
+			Multiple:   variable.Multi,
+		}}
+	case "constant":
+		return &DashboardVariable{Const: &VariableConst{
+			Name:      variable.Name,
+			Label:     variable.Label,
+			Default:   currentValue(variable.Current),
+			ValuesMap: valuesMap(),
+			Hide:      hide,
+		}}
+	case "interval":
+		values := make([]string, 0, len(variable.Options))
+		for _, option := range variable.Options {
+			values = append(values, option.Value)
+		}
+
+		return &DashboardVariable{Interval: &VariableInterval{
+			Name:    variable.Name,
+			Label:   variable.Label,
+			Default: currentValue(variable.Current),
+			Values:  values,
+			Hide:    hide,
+		}}
+	case "datasource":
+		return &DashboardVariable{Datasource: &VariableDatasource{
+			Name:       variable.Name,
+			Label:      variable.Label,
+			Type:       variableQueryString(variable.Query),
+			Regex:      variable.Regex,
+			IncludeAll: variable.IncludeAll,
+			Hide:       hide,
+			Multiple:   variable.Multi,
+		}}
+	case "textbox":
+		return &DashboardVariable{Text: &VariableText{
+			Name:  variable.Name,
+			Label: variable.Label,
+			Hide:  hide,
+		}}
+	}
+
+	exporter.warn("variable %q of type %q is not supported and was dropped", variable.Name, variable.Type)
+
+	return nil
+}
+
+func (exporter *boardExporter) exportLegacyRow(r *sdk.Row) DashboardRow {
+	row := DashboardRow{
+		Name:      r.Title,
+		Collapse:  r.Collapse,
+		HideTitle: !r.ShowTitle,
+	}
+	if r.Repeat != nil {
+		row.Repeat = *r.Repeat
+	}
+
+	for i := range r.Panels {
+		row.Panels = append(row.Panels, exporter.exportPanel(&r.Panels[i]))
+	}
+
+	return row
+}
+
+// exportPanelsLayout converts the grid layout used by recent Grafana versions
+// into rows.
+func (exporter *boardExporter) exportPanelsLayout(panels []*sdk.Panel) []DashboardRow {
+	var rows []DashboardRow
+
+	for _, panel := range panels {
+		if panel.OfType == sdk.RowType {
+			row := DashboardRow{Name: panel.Title}
+
+			if panel.Repeat != nil {
+				row.Repeat = *panel.Repeat
+			}
+			if panel.RowPanel != nil && panel.RowPanel.Collapsed {
+				row.Collapse = true
+
+				for i := range panel.RowPanel.Panels {
+					row.Panels = append(row.Panels, exporter.exportPanel(&panel.RowPanel.Panels[i]))
+				}
+			}
+
+			rows = append(rows, row)
+			continue
+		}
+
+		if len(rows) == 0 {
+			// panels defined before the first row
+			rows = append(rows, DashboardRow{HideTitle: true})
+		}
+
+		rows[len(rows)-1].Panels = append(rows[len(rows)-1].Panels, exporter.exportPanel(panel))
+	}
+
+	return rows
+}
+
+func (exporter *boardExporter) exportPanel(panel *sdk.Panel) DashboardPanel {
+	switch panel.OfType {
+	case sdk.TextType:
+		return DashboardPanel{Text: exporter.exportText(panel)}
+	case sdk.TimeseriesType:
+		return DashboardPanel{TimeSeries: exporter.exportTimeSeries(panel)}
+	case sdk.GraphType:
+		exporter.warn("panel %q: deprecated graph panel exported as a timeseries panel, its visualization settings were dropped", panel.Title)
+
+		return DashboardPanel{TimeSeries: &DashboardTimeSeries{
+			Title:       panel.Title,
+			Description: stringValue(panel.Description),
+			Span:        panelSpan(panel),
+			Height:      panelHeight(panel),
+			Transparent: panel.Transparent,
+			Datasource:  exporter.datasourceName(panel.Datasource),
+			Repeat:      stringValue(panel.Repeat),
+			Links:       exportPanelLinks(panel.Links),
+			Targets:     exporter.exportTargets(panel, panel.GraphPanel.Targets),
+		}}
+	case sdk.StatType:
+		return DashboardPanel{Stat: exporter.exportStat(panel)}
+	case sdk.SinglestatType:
+		exporter.warn("panel %q: deprecated singlestat panel exported as a stat panel, its visualization settings were dropped", panel.Title)
+
+		return DashboardPanel{Stat: &DashboardStat{
+			Title:       panel.Title,
+			Description: stringValue(panel.Description),
+			Span:        panelSpan(panel),
+			Height:      panelHeight(panel),
+			Transparent: panel.Transparent,
+			Datasource:  exporter.datasourceName(panel.Datasource),
+			Repeat:      stringValue(panel.Repeat),
+			Links:       exportPanelLinks(panel.Links),
+			Targets:     exporter.exportTargets(panel, panel.SinglestatPanel.Targets),
+		}}
+	case sdk.GaugeType:
+		return DashboardPanel{Gauge: exporter.exportGauge(panel)}
+	case sdk.LogsType:
+		return DashboardPanel{Logs: exporter.exportLogs(panel)}
+	case sdk.TableType:
+		exporter.warn("panel %q: table columns and styles were dropped", panel.Title)
+
+		return DashboardPanel{Table: &DashboardTable{
+			Title:       panel.Title,
+			Description: stringValue(panel.Description),
+			Span:        panelSpan(panel),
+			Height:      panelHeight(panel),
+			Transparent: panel.Transparent,
+			Datasource:  exporter.datasourceName(panel.Datasource),
+			Links:       exportPanelLinks(panel.Links),
+			Targets:     exporter.exportTargets(panel, panel.TablePanel.Targets),
+		}}
+	}
+
+	if libraryPanel := exportLibraryPanel(panel); libraryPanel != nil {
+		return DashboardPanel{LibraryPanel: libraryPanel}
+	}
+
+	return DashboardPanel{Custom: exporter.exportCustom(panel)}
+}
+
+func exportLibraryPanel(panel *sdk.Panel) *DashboardLibraryPanel {
+	if panel.CustomPanel == nil {
+		return nil
+	}
+
+	ref, ok := (*panel.CustomPanel)["libraryPanel"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	uid, _ := ref["uid"].(string)
+	name, _ := ref["name"].(string)
+
+	return &DashboardLibraryPanel{
+		UID:    uid,
+		Name:   name,
+		Span:   panelSpan(panel),
+		Height: panelHeight(panel),
+	}
+}
+
+func (exporter *boardExporter) exportText(panel *sdk.Panel) *DashboardText {
+	text := &DashboardText{
+		Title:       panel.Title,
+		Description: stringValue(panel.Description),
+		Span:        panelSpan(panel),
+		Height:      panelHeight(panel),
+		Transparent: panel.Transparent,
+		Links:       exportPanelLinks(panel.Links),
+	}
+
+	content, mode := panel.TextPanel.Options.Content, panel.TextPanel.Options.Mode
+	if content == "" && mode == "" {
+		content, mode = panel.TextPanel.Content, panel.TextPanel.Mode
+	}
+
+	if mode == "html" {
+		text.HTML = content
+	} else {
+		text.Markdown = content
+	}
+
+	return text
+}
+
+func (exporter *boardExporter) exportTimeSeries(panel *sdk.Panel) *DashboardTimeSeries {
+	ts := panel.TimeseriesPanel
+	defaults := ts.FieldConfig.Defaults
+
+	timeseries := &DashboardTimeSeries{
+		Title:       panel.Title,
+		Description: stringValue(panel.Description),
+		Span:        panelSpan(panel),
+		Height:      panelHeight(panel),
+		Transparent: panel.Transparent,
+		Datasource:  exporter.datasourceName(panel.Datasource),
+		Repeat:      stringValue(panel.Repeat),
+		Links:       exportPanelLinks(panel.Links),
+		Targets:     exporter.exportTargets(panel, ts.Targets),
+		Legend:      exporter.exportTimeSeriesLegend(panel),
+		Visualization: &TimeSeriesVisualization{
+			FillOpacity: nonZeroInt(defaults.Custom.FillOpacity),
+			PointSize:   nonZeroInt(defaults.Custom.PointSize),
+			LineWidth:   nonZeroInt(defaults.Custom.LineWidth),
+		},
+		Axis: &TimeSeriesAxis{
+			SoftMin:  defaults.Custom.AxisSoftMin,
+			SoftMax:  defaults.Custom.AxisSoftMax,
+			Min:      defaults.Min,
+			Max:      defaults.Max,
+			Decimals: defaults.Decimals,
+			Unit:     defaults.Unit,
+			Label:    defaults.Custom.AxisLabel,
+		},
+	}
+
+	switch defaults.Custom.GradientMode {
+	case "none", "opacity", "hue", "scheme":
+		timeseries.Visualization.GradientMode = defaults.Custom.GradientMode
+	}
+	switch defaults.Custom.LineInterpolation {
+	case "linear", "smooth":
+		timeseries.Visualization.LineInterpolation = defaults.Custom.LineInterpolation
+	case "stepBefore":
+		timeseries.Visualization.LineInterpolation = "step_before"
+	case "stepAfter":
+		timeseries.Visualization.LineInterpolation = "step_after"
+	}
+	switch defaults.Custom.Stacking.Mode {
+	case "none", "normal", "percent":
+		timeseries.Visualization.Stack = defaults.Custom.Stacking.Mode
+	}
+	switch ts.Options.Tooltip.Mode {
+	case "single":
+		timeseries.Visualization.Tooltip = "single_series"
+	case "multi":
+		timeseries.Visualization.Tooltip = "all_series"
+	case "none":
+		timeseries.Visualization.Tooltip = "none"
+	}
+	switch defaults.Custom.AxisPlacement {
+	case "auto", "left", "right", "hidden":
+		timeseries.Axis.Display = defaults.Custom.AxisPlacement
+	}
+
+	if *timeseries.Visualization == (TimeSeriesVisualization{}) {
+		timeseries.Visualization = nil
+	}
+	if *timeseries.Axis == (TimeSeriesAxis{}) {
+		timeseries.Axis = nil
+	}
+
+	if len(ts.FieldConfig.Overrides) != 0 {
+		exporter.warn("panel %q: %d field overrides were dropped", panel.Title, len(ts.FieldConfig.Overrides))
+	}
+
+	return timeseries
+}
+
+func (exporter *boardExporter) exportTimeSeriesLegend(panel *sdk.Panel) []string {
+	legend := panel.TimeseriesPanel.Options.Legend
+	attributes := []string{}
+
+	switch {
+	case legend.DisplayMode == "hidden" || (legend.Show != nil && !*legend.Show):
+		return []string{"hide"}
+	case legend.DisplayMode == "table":
+		attributes = append(attributes, "as_table")
+	}
+
+	if legend.Placement == "right" {
+		attributes = append(attributes, "to_the_right")
+	}
+
+	calcs := map[string]string{
+		"first":        "first",
+		"firstNotNull": "first_non_null",
+		"last":         "last",
+		"lastNotNull":  "last_non_null",
+		"min":          "min",
+		"max":          "max",
+		"mean":         "avg",
+		"count":        "count",
+		"sum":          "total",
+		"range":        "range",
+	}
+	for _, calc := range legend.Calcs {
+		attribute, ok := calcs[calc]
+		if !ok {
+			exporter.warn("panel %q: legend calculation %q was dropped", panel.Title, calc)
+			continue
+		}
+
+		attributes = append(attributes, attribute)
+	}
+
+	return attributes
+}
+
+func (exporter *boardExporter) exportStat(panel *sdk.Panel) *DashboardStat {
+	stat := &DashboardStat{
+		Title:       panel.Title,
+		Description: stringValue(panel.Description),
+		Span:        panelSpan(panel),
+		Height:      panelHeight(panel),
+		Transparent: panel.Transparent,
+		Datasource:  exporter.datasourceName(panel.Datasource),
+		Repeat:      stringValue(panel.Repeat),
+		Links:       exportPanelLinks(panel.Links),
+		Targets:     exporter.exportTargets(panel, panel.StatPanel.Targets),
+		Unit:        panel.StatPanel.FieldConfig.Defaults.Unit,
+		Decimals:    panel.StatPanel.FieldConfig.Defaults.Decimals,
+		SparkLine:   panel.StatPanel.Options.GraphMode == "area",
+	}
+
+	if len(panel.StatPanel.FieldConfig.Defaults.Thresholds.Steps) != 0 {
+		exporter.warn("panel %q: thresholds were dropped", panel.Title)
+	}
+
+	return stat
+}
+
+func (exporter *boardExporter) exportGauge(panel *sdk.Panel) *DashboardGauge {
+	gauge := &DashboardGauge{
+		Title:       panel.Title,
+		Description: stringValue(panel.Description),
+		Span:        panelSpan(panel),
+		Height:      panelHeight(panel),
+		Transparent: panel.Transparent,
+		Datasource:  exporter.datasourceName(panel.Datasource),
+		Repeat:      stringValue(panel.Repeat),
+		Links:       exportPanelLinks(panel.Links),
+		Targets:     exporter.exportTargets(panel, panel.GaugePanel.Targets),
+		Unit:        panel.GaugePanel.FieldConfig.Defaults.Unit,
+		Decimals:    panel.GaugePanel.FieldConfig.Defaults.Decimals,
+	}
+
+	if len(panel.GaugePanel.FieldConfig.Defaults.Thresholds.Steps) != 0 {
+		exporter.warn("panel %q: thresholds were dropped", panel.Title)
+	}
+
+	return gauge
+}
+
+func (exporter *boardExporter) exportLogs(panel *sdk.Panel) *DashboardLogs {
+	logs := &DashboardLogs{
+		Title:       panel.Title,
+		Description: stringValue(panel.Description),
+		Span:        panelSpan(panel),
+		Height:      panelHeight(panel),
+		Transparent: panel.Transparent,
+		Datasource:  exporter.datasourceName(panel.Datasource),
+		Repeat:      stringValue(panel.Repeat),
+		Links:       exportPanelLinks(panel.Links),
+	}
+
+	for _, target := range exporter.exportTargets(panel, panel.LogsPanel.Targets) {
+		if target.Loki == nil {
+			exporter.warn("panel %q: only loki targets are supported on logs panels, a target was dropped", panel.Title)
+			continue
+		}
+
+		logs.Targets = append(logs.Targets, LogsTarget{Loki: target.Loki})
+	}
+
+	return logs
+}
+
+// commonPanelKeys lists the keys of a custom panel configuration that are
+// already represented by DashboardCustom fields.
+var commonPanelKeys = []string{
+	"datasource", "editable", "error", "gridPos", "height", "id", "isNew", "links",
+	"title", "type", "description", "span", "transparent", "repeat", "renderer",
+}
+
+func (exporter *boardExporter) exportCustom(panel *sdk.Panel) *DashboardCustom {
+	custom := &DashboardCustom{
+		Title:       panel.Title,
+		Type:        panel.Type,
+		Description: stringValue(panel.Description),
+		Span:        panelSpan(panel),
+		Height:      panelHeight(panel),
+		Datasource:  exporter.datasourceName(panel.Datasource),
+		Links:       exportPanelLinks(panel.Links),
+	}
+
+	if panel.CustomPanel != nil {
+		custom.Config = make(map[string]any, len(*panel.CustomPanel))
+		for key, value := range *panel.CustomPanel {
+			custom.Config[key] = value
+		}
+		for _, key := range commonPanelKeys {
+			delete(custom.Config, key)
+		}
+	}
+
+	lost := []string{}
+	if panel.Transparent {
+		lost = append(lost, "transparency")
+	}
+	if panel.Repeat != nil {
+		lost = append(lost, "repeat")
+	}
+	if panel.CustomPanel == nil {
+		lost = append(lost, "its whole configuration")
+	}
+
+	if len(lost) == 0 {
+		exporter.warn("panel %q: %q panels are not modelled by grabana, exported as a custom panel", panel.Title, panel.Type)
+	} else {
+		exporter.warn("panel %q: %q panels are not modelled by grabana, exported as a custom panel without %v", panel.Title, panel.Type, lost)
+	}
+
+	return custom
+}
+
+func (exporter *boardExporter) exportTargets(panel *sdk.Panel, targets []sdk.Target) []Target {
+	exported := make([]Target, 0, len(targets))
+
+	panelDatasourceType := ""
+	if panel.Datasource != nil {
+		panelDatasourceType = panel.Datasource.Type
+	}
+
+	for _, target := range targets {
+		datasourceType := panelDatasourceType
+		if target.Datasource != nil && target.Datasource.Type != "" {
+			datasourceType = target.Datasource.Type
+		}
+
+		switch {
+		case target.Expr != "" && datasourceType == "loki":
+			exported = append(exported, Target{Loki: &LokiTarget{
+				Query:  target.Expr,
+				Legend: target.LegendFormat,
+				Ref:    target.RefID,
+				Hidden: target.Hide,
+			}})
+		case target.Expr != "":
+			prometheusTarget := &PrometheusTarget{
+				Query:   target.Expr,
+				Legend:  target.LegendFormat,
+				Ref:     target.RefID,
+				Hidden:  target.Hide,
+				Instant: target.Instant,
+				Format:  target.Format,
+			}
+			if target.IntervalFactor != 0 {
+				prometheusTarget.IntervalFactor = intPtr(target.IntervalFactor)
+			}
+
+			exported = append(exported, Target{Prometheus: prometheusTarget})
+		case target.Target != "":
+			exported = append(exported, Target{Graphite: &GraphiteTarget{
+				Query:  target.Target,
+				Ref:    target.RefID,
+				Hidden: target.Hide,
+			}})
+		case target.Query != "" && (datasourceType == "influxdb" || target.Measurement != ""):
+			exported = append(exported, Target{InfluxDB: &InfluxDBTarget{
+				Query:  target.Query,
+				Ref:    target.RefID,
+				Hidden: target.Hide,
+			}})
+		default:
+			exporter.warn("panel %q: target %q is not supported and was dropped", panel.Title, target.RefID)
+		}
+
+		if target.Datasource != nil && target.Datasource.UID != "" && panel.Datasource != nil && target.Datasource.UID != panel.Datasource.UID {
+			exporter.warn("panel %q: target %q uses its own datasource, the panel datasource will be used instead", panel.Title, target.RefID)
+		}
+	}
+
+	return exported
+}
+
+func (exporter *boardExporter) datasourceName(ref *sdk.DatasourceRef) string {
+	if ref == nil {
+		return ""
+	}
+	if ref.LegacyName != "" {
+		return ref.LegacyName
+	}
+	if ref.UID == "" {
+		return ""
+	}
+	if name, ok := exporter.datasourceNames[ref.UID]; ok {
+		return name
+	}
+
+	if !exporter.seenUIDs[ref.UID] {
+		exporter.seenUIDs[ref.UID] = true
+		exporter.warn("datasource with UID %q could not be translated to a name: its UID is used instead", ref.UID)
+	}
+
+	return ref.UID
+}
+
+func exportPanelLinks(links []sdk.Link) DashboardPanelLinks {
+	if len(links) == 0 {
+		return nil
+	}
+
+	exported := make(DashboardPanelLinks, 0, len(links))
+	for _, link := range links {
+		exported = append(exported, DashboardPanelLink{
+			Title:        link.Title,
+			URL:          stringValue(link.URL),
+			OpenInNewTab: link.TargetBlank != nil && *link.TargetBlank,
+		})
+	}
+
+	return exported
+}
+
+// panelSpan converts the width of a panel into grabana's 12 columns grid.
+func panelSpan(panel *sdk.Panel) float32 {
+	span := panel.Span
+	if span == 0 && panel.GridPos.W != nil {
+		// Grafana's grid has 24 columns
+		span = float32(math.Round(float64(*panel.GridPos.W) / 2))
+	}
+
+	if span == 0 {
+		return 0
+	}
+
+	return float32(math.Max(1, math.Min(12, float64(span))))
+}
+
+func panelHeight(panel *sdk.Panel) string {
+	switch height := panel.Height.(type) {
+	case string:
+		if height != "" {
+			return height
+		}
+	case float64:
+		return fmt.Sprintf("%dpx", int(height))
+	}
+
+	if panel.GridPos.H != nil {
+		// a grid unit is 30px high
+		return fmt.Sprintf("%dpx", *panel.GridPos.H*30)
+	}
+
+	return ""
+}
+
+func variableQueryString(query any) string {
+	switch q := query.(type) {
+	case string:
+		return q
+	case map[string]any:
+		if request, ok := q["query"].(string); ok {
+			return request
+		}
+	}
+
+	return ""
+}
+
+func currentValue(current sdk.Current) string {
+	switch value := current.Value.(type) {
+	case string:
+		return value
+	case []any:
+		values := make([]string, 0, len(value))
+		for _, v := range value {
+			values = append(values, fmt.Sprint(v))
+		}
+		sort.Strings(values)
+
+		if len(values) != 0 {
+			return values[0]
+		}
+	}
+
+	return ""
+}
+
+func stringValue(input *string) string {
+	if input == nil {
+		return ""
+	}
+
+	return *input
+}
+
+func nonZeroInt(input int) *int {
+	if input == 0 {
+		return nil
+	}
+
+	return intPtr(input)
+}
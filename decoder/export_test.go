@@ -0,0 +1,170 @@
+package decoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/K-Phoen/sdk"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestExportedBoardCanBeDecodedAgain(t *testing.T) {
+	req := require.New(t)
+
+	payload := `title: Awesome dashboard
+uid: awesome
+editable: true
+tags: [generated]
+auto_refresh: 30s
+time: [now-6h, now]
+timezone: utc
+
+variables:
+  - custom:
+      name: env
+      default: prod
+      values_map:
+        prod: prod
+        staging: staging
+  - query:
+      name: job
+      datasource: prometheus-default
+      request: "label_values(up, job)"
+
+rows:
+  - name: Test row
+    panels:
+      - text:
+          title: Notes
+          span: 6
+          markdown: "*markdown*"
+      - timeseries:
+          title: Requests
+          datasource: prometheus-default
+          legend: [as_table, to_the_right, avg]
+          targets:
+            - prometheus: { query: "sum(rate(requests_total[5m]))", legend: "{{ code }}", ref: A }
+      - stat:
+          title: Uptime
+          unit: s
+          targets:
+            - prometheus: { query: "time() - process_start_time_seconds" }
+`
+
+	original, err := UnmarshalYAML(bytes.NewBufferString(payload))
+	req.NoError(err)
+
+	buf, err := original.MarshalJSON()
+	req.NoError(err)
+
+	board := &sdk.Board{}
+	req.NoError(json.Unmarshal(buf, board))
+
+	model, warnings := ExportBoard(board, nil)
+	req.Empty(warnings)
+
+	exportedYAML, err := yaml.Marshal(model)
+	req.NoError(err)
+
+	decoded, err := UnmarshalYAML(bytes.NewBuffer(exportedYAML))
+	req.NoError(err)
+
+	req.Equal("Awesome dashboard", decoded.Internal().Title)
+	req.Equal("awesome", decoded.Internal().UID)
+	req.Equal("utc", decoded.Internal().Timezone)
+	req.Len(decoded.Internal().Templating.List, 2)
+	req.Len(decoded.Internal().Rows, 1)
+
+	panels := decoded.Internal().Rows[0].Panels
+	req.Len(panels, 3)
+	req.Equal("*markdown*", panels[0].TextPanel.Content)
+	req.Equal("sum(rate(requests_total[5m]))", panels[1].TimeseriesPanel.Targets[0].Expr)
+	req.Equal([]string{"mean"}, panels[1].TimeseriesPanel.Options.Legend.Calcs)
+	req.Equal("table", panels[1].TimeseriesPanel.Options.Legend.DisplayMode)
+	req.Equal("s", panels[2].StatPanel.FieldConfig.Defaults.Unit)
+}
+
+func TestExportingGridLayoutAndUnknownPanels(t *testing.T) {
+	req := require.New(t)
+
+	boardJSON := `{
+  "title": "Hand-made",
+  "panels": [
+    {"type": "timeseries", "title": "Before any row", "gridPos": {"h": 8, "w": 12, "x": 0, "y": 0},
+     "datasource": {"type": "prometheus", "uid": "prom-uid"},
+     "targets": [{"refId": "A", "expr": "up"}]},
+    {"type": "row", "title": "Collapsed", "collapsed": true, "panels": [
+      {"type": "piechart", "title": "Pie", "gridPos": {"h": 8, "w": 24, "x": 0, "y": 9},
+       "datasource": {"type": "loki", "uid": "loki-uid"},
+       "options": {"pieType": "donut"},
+       "targets": [{"refId": "A", "expr": "count_over_time({app=\"foo\"}[5m])"}]}
+    ]}
+  ]
+}`
+
+	board := &sdk.Board{}
+	req.NoError(json.Unmarshal([]byte(boardJSON), board))
+
+	model, warnings := ExportBoard(board, map[string]string{"prom-uid": "Prometheus"})
+
+	req.Len(model.Rows, 2)
+
+	req.True(model.Rows[0].HideTitle)
+	req.Len(model.Rows[0].Panels, 1)
+	timeseriesPanel := model.Rows[0].Panels[0].TimeSeries
+	req.NotNil(timeseriesPanel)
+	req.Equal("Prometheus", timeseriesPanel.Datasource)
+	req.Equal(float32(6), timeseriesPanel.Span)
+	req.Equal("240px", timeseriesPanel.Height)
+	req.Equal("up", timeseriesPanel.Targets[0].Prometheus.Query)
+
+	req.Equal("Collapsed", model.Rows[1].Name)
+	req.True(model.Rows[1].Collapse)
+	req.Len(model.Rows[1].Panels, 1)
+	customPanel := model.Rows[1].Panels[0].Custom
+	req.NotNil(customPanel)
+	req.Equal("piechart", customPanel.Type)
+	req.Equal("loki-uid", customPanel.Datasource)
+	req.Equal(float32(12), customPanel.Span)
+	req.Contains(customPanel.Config, "options")
+	req.Contains(customPanel.Config, "targets")
+	req.NotContains(customPanel.Config, "gridPos")
+
+	req.Len(warnings, 2)
+	req.Contains(warnings[0], `datasource with UID "loki-uid"`)
+	req.Contains(warnings[1], `"piechart" panels are not modelled by grabana`)
+
+	exportedYAML, err := yaml.Marshal(model)
+	req.NoError(err)
+
+	_, err = UnmarshalYAML(bytes.NewBuffer(exportedYAML))
+	req.NoError(err)
+}
+
+func TestExportingLibraryPanelReferences(t *testing.T) {
+	req := require.New(t)
+
+	boardJSON := `{
+  "title": "Hand-made",
+  "panels": [
+    {"gridPos": {"h": 8, "w": 24, "x": 0, "y": 0}, "id": 1, "libraryPanel": {"uid": "slo-burn-rate", "name": "SLO burn rate"}}
+  ]
+}`
+
+	board := &sdk.Board{}
+	req.NoError(json.Unmarshal([]byte(boardJSON), board))
+
+	model, warnings := ExportBoard(board, nil)
+
+	req.Empty(warnings)
+	req.Len(model.Rows, 1)
+	req.Len(model.Rows[0].Panels, 1)
+
+	libraryPanel := model.Rows[0].Panels[0].LibraryPanel
+	req.NotNil(libraryPanel)
+	req.Equal("slo-burn-rate", libraryPanel.UID)
+	req.Equal("SLO burn rate", libraryPanel.Name)
+	req.Equal(float32(12), libraryPanel.Span)
+}
@@ -0,0 +1,48 @@
+package decoder
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ErrUnknownKind = fmt.Errorf("unknown kind")
+
+// Kind identifies the resource described by a YAML file, through its
+// top-level "kind" key.
+type Kind string
+
+// KindDashboard is the kind of dashboards. Files without a "kind" key are
+// dashboards.
+const KindDashboard Kind = "dashboard"
+
+// KindPlaylist is the kind of playlists.
+const KindPlaylist Kind = "playlist"
+
+// DetectKind returns the kind of resource described by a YAML document.
+func DetectKind(input []byte) (Kind, error) {
+	header := struct {
+		Kind Kind
+	}{}
+	if err := yaml.Unmarshal(input, &header); err != nil {
+		return "", err
+	}
+
+	switch header.Kind {
+	case "":
+		return KindDashboard, nil
+	case KindDashboard, KindPlaylist:
+		return header.Kind, nil
+	default:
+		return "", fmt.Errorf("%w '%s'", ErrUnknownKind, header.Kind)
+	}
+}
+
+// checkKind ensures that an optional kind matches the expected one.
+func checkKind(kind Kind, expected Kind) error {
+	if kind != "" && kind != expected {
+		return fmt.Errorf("%w '%s': expected '%s'", ErrUnknownKind, kind, expected)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package decoder
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectKind(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected Kind
+	}{
+		{input: "title: Service", expected: KindDashboard},
+		{input: "kind: dashboard\ntitle: Service", expected: KindDashboard},
+		{input: "kind: playlist\nname: NOC", expected: KindPlaylist},
+	}
+
+	for _, testCase := range testCases {
+		kind, err := DetectKind([]byte(testCase.input))
+
+		require.NoError(t, err, testCase.input)
+		require.Equal(t, testCase.expected, kind, testCase.input)
+	}
+}
+
+func TestDetectKindRejectsUnknownKinds(t *testing.T) {
+	req := require.New(t)
+
+	_, err := DetectKind([]byte("kind: folder"))
+
+	req.ErrorIs(err, ErrUnknownKind)
+}
+
+func TestKindMustMatchTheDecodedResource(t *testing.T) {
+	req := require.New(t)
+
+	_, err := UnmarshalYAML(bytes.NewBufferString("kind: playlist\ntitle: Service"))
+	req.ErrorIs(err, ErrUnknownKind)
+
+	_, err = UnmarshalPlaylistYAML(bytes.NewBufferString("kind: dashboard\nname: NOC"))
+	req.ErrorIs(err, ErrUnknownKind)
+}
+
+func TestKindIsAcceptedByDecoders(t *testing.T) {
+	req := require.New(t)
+
+	dashboard, err := UnmarshalYAML(bytes.NewBufferString("kind: dashboard\ntitle: Service"))
+	req.NoError(err)
+	req.Equal("Service", dashboard.Internal().Title)
+
+	playlist, err := UnmarshalPlaylistYAML(bytes.NewBufferString("kind: playlist\nname: NOC"))
+	req.NoError(err)
+	req.Equal("NOC", playlist.Internal().Name)
+}
@@ -0,0 +1,29 @@
+package decoder
+
+import (
+	"github.com/K-Phoen/grabana/librarypanel"
+	"github.com/K-Phoen/grabana/row"
+)
+
+type DashboardLibraryPanel struct {
+	UID    string  `yaml:"uid"`
+	Name   string  `yaml:",omitempty"`
+	Span   float32 `yaml:",omitempty"`
+	Height string  `yaml:",omitempty"`
+}
+
+func (libraryPanel DashboardLibraryPanel) toOption() row.Option {
+	opts := []librarypanel.Option{}
+
+	if libraryPanel.Name != "" {
+		opts = append(opts, librarypanel.Name(libraryPanel.Name))
+	}
+	if libraryPanel.Span != 0 {
+		opts = append(opts, librarypanel.Span(libraryPanel.Span))
+	}
+	if libraryPanel.Height != "" {
+		opts = append(opts, librarypanel.Height(libraryPanel.Height))
+	}
+
+	return row.WithLibraryPanel(libraryPanel.UID, opts...)
+}
@@ -0,0 +1,426 @@
+package decoder
+
+import (
+	"fmt"
+	"time"
+
+	alert "github.com/K-Phoen/grabana/ngalert"
+	"github.com/K-Phoen/grabana/ngalert/expr"
+	"github.com/K-Phoen/grabana/ngalert/query"
+	"github.com/K-Phoen/sdk"
+)
+
+var ErrNoAlertQueryDefined = fmt.Errorf("no query defined on alert")
+var ErrNoAlertConditionRef = fmt.Errorf("no condition defined on alert")
+var ErrUnknownAlertConditionRef = fmt.Errorf("alert condition does not reference a known query or expression")
+var ErrDuplicateAlertRef = fmt.Errorf("duplicate query or expression ref on alert")
+var ErrMissingAlertRef = fmt.Errorf("missing ref on alert query or expression")
+var ErrMissingAlertQueryExpr = fmt.Errorf("missing expr on alert query")
+var ErrInvalidAlertExpression = fmt.Errorf("alert expression must define exactly one of math, reduce, resample or threshold")
+var ErrInvalidAlertReducer = fmt.Errorf("invalid alert reducer function")
+var ErrInvalidAlertReduceMode = fmt.Errorf("invalid alert reduce mode")
+var ErrInvalidAlertDownsampler = fmt.Errorf("invalid alert downsampler")
+var ErrInvalidAlertUpsampler = fmt.Errorf("invalid alert upsampler")
+var ErrInvalidAlertThreshold = fmt.Errorf("alert threshold must define exactly one of above, below, within_range or outside_range")
+
+// UnifiedAlert represents a Grafana unified alert rule (ngalert).
+type UnifiedAlert struct {
+	Title       string
+	Summary     string            `yaml:",omitempty"`
+	Description string            `yaml:",omitempty"`
+	Runbook     string            `yaml:",omitempty"`
+	Labels      map[string]string `yaml:",omitempty"`
+	Annotations map[string]string `yaml:",omitempty"`
+
+	For              string `yaml:",omitempty"`
+	OnNoData         string `yaml:"on_no_data,omitempty"`
+	OnExecutionError string `yaml:"on_execution_error,omitempty"`
+
+	// Condition is the ref of the query or expression used as alert condition.
+	Condition   string
+	Queries     []UnifiedAlertQuery
+	Expressions []UnifiedAlertExpression `yaml:",omitempty"`
+}
+
+// UnifiedAlertQuery represents a datasource query used by a unified alert.
+type UnifiedAlertQuery struct {
+	Ref        string
+	Datasource string `yaml:",omitempty"`
+	Expr       string
+	Legend     string `yaml:",omitempty"`
+	Instant    bool   `yaml:",omitempty"`
+	From       string `yaml:",omitempty"`
+	To         string `yaml:",omitempty"`
+}
+
+// UnifiedAlertExpression represents a server-side expression used by a
+// unified alert. Exactly one of its steps must be defined.
+type UnifiedAlertExpression struct {
+	Ref       string
+	Math      string                 `yaml:",omitempty"`
+	Reduce    *UnifiedAlertReduce    `yaml:",omitempty"`
+	Resample  *UnifiedAlertResample  `yaml:",omitempty"`
+	Threshold *UnifiedAlertThreshold `yaml:",omitempty"`
+}
+
+// UnifiedAlertReduce reduces the series returned by another query or expression.
+type UnifiedAlertReduce struct {
+	Expression  string
+	Function    string
+	Mode        string   `yaml:",omitempty"`
+	ReplaceWith *float64 `yaml:"replace_with,omitempty"`
+}
+
+// UnifiedAlertResample resamples the series returned by another query or expression.
+type UnifiedAlertResample struct {
+	Expression  string
+	Window      string
+	Downsampler string
+	Upsampler   string
+}
+
+// UnifiedAlertThreshold compares the values returned by another query or
+// expression to a threshold.
+type UnifiedAlertThreshold struct {
+	Expression   string
+	Above        *float64  `yaml:",omitempty"`
+	Below        *float64  `yaml:",omitempty"`
+	WithinRange  []float64 `yaml:"within_range,omitempty,flow"`
+	OutsideRange []float64 `yaml:"outside_range,omitempty,flow"`
+}
+
+func (a UnifiedAlert) toOptions() ([]alert.Option, error) {
+	if len(a.Queries) == 0 {
+		return nil, ErrNoAlertQueryDefined
+	}
+	if a.Condition == "" {
+		return nil, ErrNoAlertConditionRef
+	}
+	if err := a.validateRefs(); err != nil {
+		return nil, err
+	}
+
+	opts := []alert.Option{}
+
+	if a.For != "" {
+		opts = append(opts, alert.For(a.For))
+	}
+	if a.OnNoData != "" {
+		opt, err := a.noDataOption()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, opt)
+	}
+	if a.OnExecutionError != "" {
+		opt, err := a.executionErrorOption()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, opt)
+	}
+	if a.Summary != "" {
+		opts = append(opts, alert.Summary(a.Summary))
+	}
+	if a.Description != "" {
+		opts = append(opts, alert.Description(a.Description))
+	}
+	if a.Runbook != "" {
+		opts = append(opts, alert.Runbook(a.Runbook))
+	}
+	for key, value := range a.Annotations {
+		opts = append(opts, alert.Annotate(key, value))
+	}
+	for key, value := range a.Labels {
+		opts = append(opts, alert.Label(key, value))
+	}
+
+	for _, q := range a.Queries {
+		opt, err := q.toOption(a.Condition)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, opt)
+	}
+
+	for _, e := range a.Expressions {
+		opt, err := e.toOption(a.Condition)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, opt)
+	}
+
+	return opts, nil
+}
+
+func (a UnifiedAlert) validateRefs() error {
+	refs := make(map[string]struct{}, len(a.Queries)+len(a.Expressions))
+
+	register := func(ref string) error {
+		if ref == "" {
+			return ErrMissingAlertRef
+		}
+		if _, exists := refs[ref]; exists {
+			return fmt.Errorf("%w: %s", ErrDuplicateAlertRef, ref)
+		}
+
+		refs[ref] = struct{}{}
+
+		return nil
+	}
+
+	for _, q := range a.Queries {
+		if err := register(q.Ref); err != nil {
+			return err
+		}
+	}
+	for _, e := range a.Expressions {
+		if err := register(e.Ref); err != nil {
+			return err
+		}
+	}
+
+	if _, exists := refs[a.Condition]; !exists {
+		return fmt.Errorf("%w: %s", ErrUnknownAlertConditionRef, a.Condition)
+	}
+
+	return nil
+}
+
+func (a UnifiedAlert) noDataOption() (alert.Option, error) {
+	var state sdk.NoDataState
+
+	switch a.OnNoData {
+	case "no_data":
+		state = sdk.NoDataStateNoData
+	case "alerting":
+		state = sdk.NoDataStateAlerting
+	case "ok":
+		state = sdk.NoDataStateOk
+	default:
+		return nil, fmt.Errorf("unknown on_no_data mode '%s'", a.OnNoData)
+	}
+
+	return alert.OnNoData(state), nil
+}
+
+func (a UnifiedAlert) executionErrorOption() (alert.Option, error) {
+	var state sdk.ExecErrorState
+
+	switch a.OnExecutionError {
+	case "alerting":
+		state = sdk.ExecErrorStateAlerting
+	case "error":
+		state = sdk.ExecErrorStateError
+	case "ok":
+		state = sdk.ExecErrorStateOk
+	default:
+		return nil, fmt.Errorf("unknown on_execution_error mode '%s'", a.OnExecutionError)
+	}
+
+	return alert.OnExecutionError(state), nil
+}
+
+func (q UnifiedAlertQuery) toOption(condition string) (alert.Option, error) {
+	if q.Expr == "" {
+		return nil, fmt.Errorf("%w: %s", ErrMissingAlertQueryExpr, q.Ref)
+	}
+
+	opts := []query.Option{
+		query.Expr(q.Expr),
+	}
+
+	if q.Datasource != "" {
+		opts = append(opts, query.Datasource(q.Datasource))
+	}
+	if q.Legend != "" {
+		opts = append(opts, query.Legend(q.Legend))
+	}
+	if q.Instant {
+		opts = append(opts, query.Instant())
+	}
+	if q.From != "" || q.To != "" {
+		opt, err := q.timeRangeOption()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, opt)
+	}
+	if q.Ref == condition {
+		opts = append(opts, query.AlertCondition())
+	}
+
+	return alert.Query(q.Ref, opts...), nil
+}
+
+func (q UnifiedAlertQuery) timeRangeOption() (query.Option, error) {
+	from := 10 * time.Minute
+	to := time.Duration(0)
+
+	if q.From != "" {
+		duration, err := time.ParseDuration(q.From)
+		if err != nil {
+			return nil, fmt.Errorf("invalid alert query 'from': %w", err)
+		}
+
+		from = duration
+	}
+	if q.To != "" {
+		duration, err := time.ParseDuration(q.To)
+		if err != nil {
+			return nil, fmt.Errorf("invalid alert query 'to': %w", err)
+		}
+
+		to = duration
+	}
+
+	return query.TimeRange(from, to), nil
+}
+
+func (e UnifiedAlertExpression) toOption(condition string) (alert.Option, error) {
+	opt, err := e.commandOption()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []expr.Option{opt}
+	if e.Ref == condition {
+		opts = append(opts, expr.AlertCondition())
+	}
+
+	return alert.Expr(e.Ref, opts...), nil
+}
+
+func (e UnifiedAlertExpression) commandOption() (expr.Option, error) {
+	defined := 0
+	for _, isSet := range []bool{e.Math != "", e.Reduce != nil, e.Resample != nil, e.Threshold != nil} {
+		if isSet {
+			defined++
+		}
+	}
+	if defined != 1 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAlertExpression, e.Ref)
+	}
+
+	if e.Math != "" {
+		return expr.Math(e.Math), nil
+	}
+	if e.Reduce != nil {
+		return e.Reduce.toOption()
+	}
+	if e.Resample != nil {
+		return e.Resample.toOption()
+	}
+
+	return e.Threshold.toOption()
+}
+
+func (r UnifiedAlertReduce) toOption() (expr.Option, error) {
+	var reducer sdk.ReducerFunc
+
+	switch r.Function {
+	case "sum":
+		reducer = sdk.ReducerFuncSum
+	case "mean":
+		reducer = sdk.ReducerFuncMean
+	case "min":
+		reducer = sdk.ReducerFuncMin
+	case "max":
+		reducer = sdk.ReducerFuncMax
+	case "count":
+		reducer = sdk.ReducerFuncCount
+	case "last":
+		reducer = sdk.ReducerFuncLast
+	default:
+		return nil, ErrInvalidAlertReducer
+	}
+
+	opts := []expr.ReducerOption{}
+
+	switch r.Mode {
+	case "", "strict":
+	case "drop_non_numeric":
+		opts = append(opts, expr.ReduceDropNaN())
+	case "replace_non_numeric":
+		replaceWith := float64(0)
+		if r.ReplaceWith != nil {
+			replaceWith = *r.ReplaceWith
+		}
+
+		opts = append(opts, expr.ReduceReplaceNaN(replaceWith))
+	default:
+		return nil, ErrInvalidAlertReduceMode
+	}
+
+	return expr.Reduce(r.Expression, reducer, opts...), nil
+}
+
+func (r UnifiedAlertResample) toOption() (expr.Option, error) {
+	var down sdk.ResampleDownSampler
+	var up sdk.ResampleUpSampler
+
+	switch r.Downsampler {
+	case "sum":
+		down = sdk.ResampleDownSamplerSum
+	case "mean":
+		down = sdk.ResampleDownSamplerMean
+	case "min":
+		down = sdk.ResampleDownSamplerMin
+	case "max":
+		down = sdk.ResampleDownSamplerMax
+	case "last":
+		down = sdk.ResampleDownSamplerLast
+	default:
+		return nil, ErrInvalidAlertDownsampler
+	}
+
+	switch r.Upsampler {
+	case "pad":
+		up = sdk.ResampleUpSamplerPad
+	case "backfilling":
+		up = sdk.ResampleUpSamplerBackFilling
+	case "fillna":
+		up = sdk.ResampleUpSamplerFillNa
+	default:
+		return nil, ErrInvalidAlertUpsampler
+	}
+
+	return expr.Resample(r.Expression, r.Window, down, up), nil
+}
+
+func (t UnifiedAlertThreshold) toOption() (expr.Option, error) {
+	var opts []expr.ThresholdOption
+
+	if t.Above != nil {
+		opts = append(opts, expr.Gt(*t.Above))
+	}
+	if t.Below != nil {
+		opts = append(opts, expr.Lt(*t.Below))
+	}
+	if t.WithinRange != nil {
+		if len(t.WithinRange) != 2 {
+			return nil, ErrInvalidAlertThreshold
+		}
+
+		opts = append(opts, expr.WithinRange(t.WithinRange[0], t.WithinRange[1]))
+	}
+	if t.OutsideRange != nil {
+		if len(t.OutsideRange) != 2 {
+			return nil, ErrInvalidAlertThreshold
+		}
+
+		opts = append(opts, expr.OutsideRange(t.OutsideRange[0], t.OutsideRange[1]))
+	}
+
+	if len(opts) != 1 {
+		return nil, ErrInvalidAlertThreshold
+	}
+
+	return expr.Threshold(t.Expression, opts[0]), nil
+}
@@ -0,0 +1,265 @@
+package decoder
+
+import (
+	"bytes"
+	"testing"
+
+	alert "github.com/K-Phoen/grabana/ngalert"
+	"github.com/K-Phoen/sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodingUnifiedAlert(t *testing.T) {
+	req := require.New(t)
+
+	alertDef := UnifiedAlert{
+		Title:            "Too many errors",
+		Summary:          "summary",
+		Description:      "description",
+		Runbook:          "runbook",
+		Labels:           map[string]string{"severity": "page"},
+		Annotations:      map[string]string{"team": "platform"},
+		For:              "2m",
+		OnNoData:         "ok",
+		OnExecutionError: "error",
+		Condition:        "C",
+		Queries: []UnifiedAlertQuery{
+			{Ref: "A", Datasource: "prometheus-default", Expr: "sum(rate(errors_total[5m]))", Instant: true, From: "5m"},
+		},
+		Expressions: []UnifiedAlertExpression{
+			{Ref: "B", Reduce: &UnifiedAlertReduce{Expression: "A", Function: "last", Mode: "drop_non_numeric"}},
+			{Ref: "C", Threshold: &UnifiedAlertThreshold{Expression: "B", Above: float64Ptr(3)}},
+		},
+	}
+
+	opts, err := alertDef.toOptions()
+	req.NoError(err)
+
+	rule := alert.New(alertDef.Title, opts...).Builder
+
+	req.Equal("Too many errors", rule.Title)
+	req.Equal("C", rule.Condition)
+	req.Equal("2m", rule.For)
+	req.Equal(sdk.NoDataStateOk, rule.NoDataState)
+	req.Equal(sdk.ExecErrorStateError, rule.ExecErrState)
+	req.Equal("summary", rule.Annotations["summary"])
+	req.Equal("description", rule.Annotations["description"])
+	req.Equal("runbook", rule.Annotations["runbook_url"])
+	req.Equal("platform", rule.Annotations["team"])
+	req.Equal("page", rule.Labels["severity"])
+
+	req.Len(rule.Data, 3)
+
+	req.Equal("A", rule.Data[0].RefId)
+	req.Equal("prometheus-default", rule.Data[0].DatasourceUid)
+	req.Equal(300, rule.Data[0].RelativeTimeRange.FromSeconds)
+	req.True(rule.Data[0].Model.NgAlertQueryModelQuery.Instant)
+
+	req.Equal("B", rule.Data[1].RefId)
+	req.Equal(sdk.CommandTypeReduce, rule.Data[1].Model.NgAlertQueryModelExpression.Cmd.Type)
+	req.Equal(sdk.ReducerFuncLast, rule.Data[1].Model.NgAlertQueryModelExpression.Cmd.ReduceCommand.Reducer)
+	req.Equal(sdk.ReduceModeDropNonNumeric, rule.Data[1].Model.NgAlertQueryModelExpression.Cmd.ReduceCommand.Settings.Mode)
+
+	req.Equal("C", rule.Data[2].RefId)
+	req.Equal(sdk.CommandTypeThreshold, rule.Data[2].Model.NgAlertQueryModelExpression.Cmd.Type)
+	req.Equal([]float64{3}, rule.Data[2].Model.NgAlertQueryModelExpression.Cmd.ThresholdCommand.Conditions[0].Evaluator.Params)
+}
+
+func TestDecodingUnifiedAlertWithMathAndResample(t *testing.T) {
+	req := require.New(t)
+
+	alertDef := UnifiedAlert{
+		Title:     "Math",
+		Condition: "C",
+		Queries: []UnifiedAlertQuery{
+			{Ref: "A", Expr: "up"},
+		},
+		Expressions: []UnifiedAlertExpression{
+			{Ref: "B", Resample: &UnifiedAlertResample{Expression: "A", Window: "1m", Downsampler: "mean", Upsampler: "pad"}},
+			{Ref: "C", Math: "$B > 1"},
+		},
+	}
+
+	opts, err := alertDef.toOptions()
+	req.NoError(err)
+
+	rule := alert.New(alertDef.Title, opts...).Builder
+
+	req.Equal(sdk.CommandTypeResample, rule.Data[1].Model.NgAlertQueryModelExpression.Cmd.Type)
+	req.Equal("1m", rule.Data[1].Model.NgAlertQueryModelExpression.Cmd.ResampleCommand.Window)
+	req.Equal(sdk.CommandTypeMath, rule.Data[2].Model.NgAlertQueryModelExpression.Cmd.Type)
+	req.Equal("$B > 1", rule.Data[2].Model.NgAlertQueryModelExpression.Cmd.MathCommand.Expression)
+}
+
+func TestDecodingUnifiedAlertErrors(t *testing.T) {
+	validQueries := []UnifiedAlertQuery{{Ref: "A", Expr: "up"}}
+
+	testCases := []struct {
+		name     string
+		alert    UnifiedAlert
+		expected error
+	}{
+		{
+			name:     "no query",
+			alert:    UnifiedAlert{Condition: "A"},
+			expected: ErrNoAlertQueryDefined,
+		},
+		{
+			name:     "no condition",
+			alert:    UnifiedAlert{Queries: validQueries},
+			expected: ErrNoAlertConditionRef,
+		},
+		{
+			name:     "unknown condition",
+			alert:    UnifiedAlert{Queries: validQueries, Condition: "Z"},
+			expected: ErrUnknownAlertConditionRef,
+		},
+		{
+			name: "duplicate ref",
+			alert: UnifiedAlert{
+				Queries:     validQueries,
+				Expressions: []UnifiedAlertExpression{{Ref: "A", Math: "$A"}},
+				Condition:   "A",
+			},
+			expected: ErrDuplicateAlertRef,
+		},
+		{
+			name:     "missing query expr",
+			alert:    UnifiedAlert{Queries: []UnifiedAlertQuery{{Ref: "A"}}, Condition: "A"},
+			expected: ErrMissingAlertQueryExpr,
+		},
+		{
+			name: "empty expression",
+			alert: UnifiedAlert{
+				Queries:     validQueries,
+				Expressions: []UnifiedAlertExpression{{Ref: "B"}},
+				Condition:   "B",
+			},
+			expected: ErrInvalidAlertExpression,
+		},
+		{
+			name: "invalid reducer",
+			alert: UnifiedAlert{
+				Queries:     validQueries,
+				Expressions: []UnifiedAlertExpression{{Ref: "B", Reduce: &UnifiedAlertReduce{Expression: "A", Function: "median"}}},
+				Condition:   "B",
+			},
+			expected: ErrInvalidAlertReducer,
+		},
+		{
+			name: "invalid threshold",
+			alert: UnifiedAlert{
+				Queries:     validQueries,
+				Expressions: []UnifiedAlertExpression{{Ref: "B", Threshold: &UnifiedAlertThreshold{Expression: "A", WithinRange: []float64{1}}}},
+				Condition:   "B",
+			},
+			expected: ErrInvalidAlertThreshold,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.alert.toOptions()
+
+			require.ErrorIs(t, err, tc.expected)
+		})
+	}
+}
+
+func TestUnmarshalYAMLWithUnifiedAlerts(t *testing.T) {
+	req := require.New(t)
+
+	payload := `title: Awesome dashboard
+
+rows:
+  - name: Test row
+    panels:
+      - timeseries:
+          title: Errors
+          datasource: prometheus-default
+          targets:
+            - prometheus: { query: "sum(rate(errors_total[5m]))" }
+          alerts:
+            - title: Too many errors
+              summary: Way too many errors
+              for: 1m
+              on_no_data: alerting
+              labels: { severity: page }
+              condition: C
+              queries:
+                - { ref: A, expr: "sum(rate(errors_total[5m]))" }
+              expressions:
+                - ref: B
+                  reduce: { expression: A, function: mean }
+                - ref: C
+                  threshold: { expression: B, above: 10 }
+      - custom:
+          title: Pie
+          type: grafana-piechart-panel
+          datasource: prometheus-default
+          alerts:
+            - title: Pie is sad
+              condition: A
+              queries:
+                - { ref: A, expr: "up == 0" }
+
+alerts:
+  - title: Standalone
+    condition: B
+    queries:
+      - { ref: A, datasource: loki, expr: "count_over_time({app=\"foo\"}[5m])" }
+    expressions:
+      - ref: B
+        math: "$A > 0"
+`
+
+	builder, err := UnmarshalYAML(bytes.NewBufferString(payload))
+	req.NoError(err)
+
+	req.Len(builder.Alerts, 3)
+
+	req.Equal("Too many errors", builder.Alerts[0].Builder.Title)
+	req.Equal("Errors", *builder.Alerts[0].RefPanelTitle)
+	req.Equal("prometheus-default", builder.Alerts[0].Builder.Data[0].DatasourceUid)
+
+	req.Equal("Pie is sad", builder.Alerts[1].Builder.Title)
+	req.Equal("Pie", *builder.Alerts[1].RefPanelTitle)
+	req.Equal("prometheus-default", builder.Alerts[1].Builder.Data[0].DatasourceUid)
+
+	req.Equal("Standalone", builder.Alerts[2].Builder.Title)
+	req.Nil(builder.Alerts[2].RefPanelTitle)
+	req.Equal("loki", builder.Alerts[2].Builder.Data[0].DatasourceUid)
+
+	req.Len(builder.Internal().Rows[0].Panels, 2)
+	req.Equal("grafana-piechart-panel", builder.Internal().Rows[0].Panels[1].Type)
+}
+
+func TestUnmarshalYAMLRejectsLegacyTimeseriesAlerts(t *testing.T) {
+	req := require.New(t)
+
+	payload := `title: Awesome dashboard
+
+rows:
+  - name: Test row
+    panels:
+      - timeseries:
+          title: Errors
+          targets:
+            - prometheus: { query: "sum(rate(errors_total[5m]))" }
+          alert:
+            summary: Too many errors
+            evaluate_every: 1m
+            for: 1m
+            if:
+              - { avg: A, above: 10 }
+            targets:
+              - prometheus: { ref: A, query: "sum(rate(errors_total[5m]))" }
+`
+
+	_, err := UnmarshalYAML(bytes.NewBufferString(payload))
+
+	req.ErrorIs(err, ErrLegacyTimeSeriesAlert)
+	req.Contains(err.Error(), "Errors")
+}
@@ -0,0 +1,74 @@
+package decoder
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/K-Phoen/grabana/playlist"
+	"gopkg.in/yaml.v3"
+)
+
+var ErrInvalidPlaylistItem = fmt.Errorf("playlist item must define exactly one of dashboard_uid or tag")
+
+type PlaylistModel struct {
+	Kind     Kind `yaml:",omitempty"`
+	Name     string
+	UID      string `yaml:"uid,omitempty"`
+	Interval string `yaml:",omitempty"`
+
+	Items []PlaylistItem
+}
+
+type PlaylistItem struct {
+	DashboardUID string `yaml:"dashboard_uid,omitempty"`
+	Tag          string `yaml:",omitempty"`
+}
+
+// UnmarshalPlaylistYAML decodes a YAML playlist definition.
+func UnmarshalPlaylistYAML(input io.Reader) (playlist.Builder, error) {
+	decoder := yaml.NewDecoder(input)
+	decoder.KnownFields(true)
+
+	parsed := &PlaylistModel{}
+	if err := decoder.Decode(parsed); err != nil {
+		return playlist.Builder{}, err
+	}
+	if err := checkKind(parsed.Kind, KindPlaylist); err != nil {
+		return playlist.Builder{}, err
+	}
+
+	return parsed.ToBuilder()
+}
+
+func (p *PlaylistModel) ToBuilder() (playlist.Builder, error) {
+	opts := []playlist.Option{}
+
+	if p.UID != "" {
+		opts = append(opts, playlist.UID(p.UID))
+	}
+	if p.Interval != "" {
+		opts = append(opts, playlist.Interval(p.Interval))
+	}
+
+	for _, item := range p.Items {
+		opt, err := item.toOption()
+		if err != nil {
+			return playlist.Builder{}, err
+		}
+
+		opts = append(opts, opt)
+	}
+
+	return playlist.New(p.Name, opts...)
+}
+
+func (item PlaylistItem) toOption() (playlist.Option, error) {
+	switch {
+	case item.DashboardUID != "" && item.Tag == "":
+		return playlist.DashboardsByUID(item.DashboardUID), nil
+	case item.Tag != "" && item.DashboardUID == "":
+		return playlist.DashboardsByTag(item.Tag), nil
+	}
+
+	return nil, ErrInvalidPlaylistItem
+}
@@ -0,0 +1,48 @@
+package decoder
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/K-Phoen/grabana/playlist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalPlaylistYAML(t *testing.T) {
+	req := require.New(t)
+
+	builder, err := UnmarshalPlaylistYAML(bytes.NewBufferString(`
+name: NOC wall
+uid: noc-wall
+interval: 2m
+items:
+  - dashboard_uid: overview
+  - tag: noc
+`))
+	req.NoError(err)
+
+	req.Equal("NOC wall", builder.Internal().Name)
+	req.Equal("noc-wall", builder.Internal().UID)
+	req.Equal("2m", builder.Internal().Interval)
+	req.Equal([]playlist.Item{
+		{Type: playlist.ItemDashboardByUID, Value: "overview"},
+		{Type: playlist.ItemDashboardByTag, Value: "noc"},
+	}, builder.Internal().Items)
+}
+
+func TestUnmarshalPlaylistYAMLWithInvalidInput(t *testing.T) {
+	_, err := UnmarshalPlaylistYAML(bytes.NewBufferString(""))
+
+	require.Error(t, err)
+}
+
+func TestUnmarshalPlaylistYAMLRejectsAmbiguousItems(t *testing.T) {
+	_, err := UnmarshalPlaylistYAML(bytes.NewBufferString(`
+name: NOC wall
+items:
+  - dashboard_uid: overview
+    tag: noc
+`))
+
+	require.ErrorIs(t, err, ErrInvalidPlaylistItem)
+}
@@ -16,18 +16,23 @@ var ErrInvalidStackMode = fmt.Errorf("invalid stack mode")
 var ErrInvalidAxisDisplay = fmt.Errorf("invalid axis display")
 var ErrInvalidAxisScale = fmt.Errorf("invalid axis scale")
 var ErrInvalidOverrideMatcher = fmt.Errorf("invalid override matcher")
+var ErrLegacyTimeSeriesAlert = fmt.Errorf("legacy alerts are not supported on timeseries panels: use 'alerts' instead of 'alert'")
 
 type DashboardTimeSeries struct {
-	Title         string
-	Description   string              `yaml:",omitempty"`
-	Span          float32             `yaml:",omitempty"`
-	Height        string              `yaml:",omitempty"`
-	Transparent   bool                `yaml:",omitempty"`
-	Datasource    string              `yaml:",omitempty"`
-	Repeat        string              `yaml:",omitempty"`
-	Links         DashboardPanelLinks `yaml:",omitempty"`
-	Targets       []Target
-	Legend        []string                 `yaml:",omitempty,flow"`
+	Title       string
+	Description string              `yaml:",omitempty"`
+	Span        float32             `yaml:",omitempty"`
+	Height      string              `yaml:",omitempty"`
+	Transparent bool                `yaml:",omitempty"`
+	Datasource  string              `yaml:",omitempty"`
+	Repeat      string              `yaml:",omitempty"`
+	Links       DashboardPanelLinks `yaml:",omitempty"`
+	Targets     []Target
+	Legend      []string       `yaml:",omitempty,flow"`
+	Alerts      []UnifiedAlert `yaml:",omitempty"`
+	// Alert is the legacy alert definition. It is only decoded to reject it
+	// with an explicit error: use Alerts instead.
+	// Deprecated.
 	Alert         *Alert                   `yaml:",omitempty"`
 	Visualization *TimeSeriesVisualization `yaml:",omitempty"`
 	Axis          *TimeSeriesAxis          `yaml:",omitempty"`
@@ -35,6 +40,10 @@ type DashboardTimeSeries struct {
 }
 
 func (timeseriesPanel DashboardTimeSeries) toOption() (row.Option, error) {
+	if timeseriesPanel.Alert != nil {
+		return nil, fmt.Errorf("panel '%s': %w", timeseriesPanel.Title, ErrLegacyTimeSeriesAlert)
+	}
+
 	opts := []timeseries.Option{}
 
 	if timeseriesPanel.Description != "" {
@@ -66,14 +75,13 @@ func (timeseriesPanel DashboardTimeSeries) toOption() (row.Option, error) {
 
 		opts = append(opts, timeseries.Legend(legendOpts...))
 	}
-	if timeseriesPanel.Alert != nil {
-		// deprecated alerts
-		//alertOpts, err := timeseriesPanel.Alert.toOptions()
-		//if err != nil {
-		//	return nil, err
-		//}
-		//
-		//opts = append(opts, timeseries.Alert(timeseriesPanel.Alert.Summary, alertOpts...))
+	for _, a := range timeseriesPanel.Alerts {
+		alertOpts, err := a.toOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, timeseries.Alert(a.Title, alertOpts...))
 	}
 	if timeseriesPanel.Visualization != nil {
 		vizOpts, err := timeseriesPanel.Visualization.toOptions()
@@ -15,6 +15,9 @@ func UnmarshalYAML(input io.Reader) (dashboard.Builder, error) {
 	if err := decoder.Decode(parsed); err != nil {
 		return dashboard.Builder{}, err
 	}
+	if err := checkKind(parsed.Kind, KindDashboard); err != nil {
+		return dashboard.Builder{}, err
+	}
 
 	return parsed.ToBuilder()
 }
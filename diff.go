@@ -0,0 +1,539 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/K-Phoen/grabana/dashboard"
+	"github.com/K-Phoen/sdk"
+)
+
+// ChangeKind describes how a resource differs between a dashboard builder
+// and its live counterpart in Grafana.
+type ChangeKind string
+
+const (
+	// Added means that the resource does not exist in Grafana yet.
+	Added ChangeKind = "+"
+	// Removed means that the resource exists in Grafana but not in the builder.
+	Removed ChangeKind = "-"
+	// Changed means that the resource exists on both sides, with different values.
+	Changed ChangeKind = "~"
+)
+
+// FieldChange describes a single field that differs.
+type FieldChange struct {
+	Path string
+	Old  any
+	New  any
+}
+
+// Change describes a resource (panel, row, variable, alert rule, …) that
+// differs between a dashboard builder and Grafana.
+type Change struct {
+	Kind     ChangeKind
+	Resource string
+	Name     string
+	Fields   []FieldChange
+}
+
+// DashboardDiff holds the structural differences between a dashboard builder
+// and the dashboard currently stored in Grafana.
+type DashboardDiff struct {
+	Title string
+	// Exists is false when the dashboard does not exist in Grafana yet.
+	Exists bool
+	// UID is the UID of the dashboard stored in Grafana, when it exists.
+	UID      string
+	Settings []FieldChange
+	Changes  []Change
+}
+
+// HasChanges tells if applying the builder would change anything in Grafana.
+func (diff DashboardDiff) HasChanges() bool {
+	return !diff.Exists || len(diff.Settings) != 0 || len(diff.Changes) != 0
+}
+
+// String renders the diff in a human-readable format.
+func (diff DashboardDiff) String() string {
+	var buffer strings.Builder
+
+	switch {
+	case !diff.Exists:
+		fmt.Fprintf(&buffer, "%s dashboard %q\n", Added, diff.Title)
+	case diff.HasChanges():
+		fmt.Fprintf(&buffer, "%s dashboard %q\n", Changed, diff.Title)
+	default:
+		fmt.Fprintf(&buffer, "  dashboard %q (no changes)\n", diff.Title)
+	}
+
+	for _, field := range diff.Settings {
+		fmt.Fprintf(&buffer, "    %s %s: %s => %s\n", Changed, field.Path, formatDiffValue(field.Old), formatDiffValue(field.New))
+	}
+
+	for _, change := range diff.Changes {
+		fmt.Fprintf(&buffer, "  %s %s %q\n", change.Kind, change.Resource, change.Name)
+
+		for _, field := range change.Fields {
+			fmt.Fprintf(&buffer, "      %s: %s => %s\n", field.Path, formatDiffValue(field.Old), formatDiffValue(field.New))
+		}
+	}
+
+	return buffer.String()
+}
+
+// DiffDashboard compares the given dashboard builder with its live version,
+// found by UID or by title in the given folder. Server-managed fields (IDs,
+// versions, …) are ignored. A nil folder means that the folder does not
+// exist yet.
+func (client *Client) DiffDashboard(ctx context.Context, folder *Folder, builder dashboard.Builder) (*DashboardDiff, error) {
+	desired := builder.Internal()
+
+	live, err := client.findLiveDashboard(ctx, folder, desired)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := diffBoards(desired, live)
+	if err != nil {
+		return nil, err
+	}
+	if live == nil {
+		live = &sdk.Board{}
+	}
+	diff.UID = live.UID
+
+	alertChanges, err := client.diffAlerts(ctx, folder, builder, live)
+	if err != nil {
+		return nil, err
+	}
+	diff.Changes = append(diff.Changes, alertChanges...)
+
+	return diff, nil
+}
+
+// diffBoards compares the settings, rows, panels and variables of two
+// boards. A nil live board is compared as an empty one.
+func diffBoards(desired *sdk.Board, live *sdk.Board) (*DashboardDiff, error) {
+	var err error
+
+	diff := &DashboardDiff{Title: desired.Title, Exists: live != nil}
+	if live == nil {
+		live = &sdk.Board{}
+	}
+
+	diff.Settings, err = diffBoardSettings(desired, live)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, differ := range []func(desired *sdk.Board, live *sdk.Board) ([]Change, error){diffRows, diffPanels, diffVariables} {
+		changes, err := differ(desired, live)
+		if err != nil {
+			return nil, err
+		}
+
+		diff.Changes = append(diff.Changes, changes...)
+	}
+
+	return diff, nil
+}
+
+func (client *Client) findLiveDashboard(ctx context.Context, folder *Folder, desired *sdk.Board) (*sdk.Board, error) {
+	uid := desired.UID
+
+	if uid == "" {
+		if folder == nil {
+			return nil, nil
+		}
+
+		found, err := client.searchDashboardByTitle(ctx, folder, desired.Title)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+
+		uid = found.UID
+	}
+
+	board, err := client.GetDashboardByUID(ctx, uid)
+	if errors.Is(err, ErrDashboardNotFound) {
+		return nil, nil
+	}
+
+	return board, err
+}
+
+func (client *Client) diffAlerts(ctx context.Context, folder *Folder, builder dashboard.Builder, live *sdk.Board) ([]Change, error) {
+	var liveRules []map[string]any
+
+	if live.UID != "" && folder != nil {
+		var rules []map[string]any
+		if err := client.listAlertRules(ctx, &rules); err != nil {
+			return nil, err
+		}
+
+		for _, rule := range rules {
+			annotations, _ := rule["annotations"].(map[string]any)
+			dashboardUID, _ := annotations["__dashboardUid__"].(string)
+			if dashboardUID == "" {
+				dashboardUID, _ = annotations[customDashboardRefKey].(string)
+			}
+
+			if dashboardUID != live.UID || rule["ruleGroup"] != builder.Internal().Title || rule["folderUID"] != folder.UID {
+				continue
+			}
+
+			liveRules = append(liveRules, rule)
+		}
+	}
+
+	var datasourcesMap map[string]string
+	if len(builder.Alerts) != 0 {
+		var err error
+		if datasourcesMap, err = client.datasourcesUIDMap(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	desiredRules := make([]map[string]any, 0, len(builder.Alerts))
+	for _, alertDef := range builder.Alerts {
+		rule, err := normalizeForDiff(alertDef.Builder)
+		if err != nil {
+			return nil, err
+		}
+
+		data, _ := rule["data"].([]any)
+		for _, item := range data {
+			query, _ := item.(map[string]any)
+			if query == nil || query["datasourceUid"] == "__expr__" {
+				continue
+			}
+			name, _ := query["datasourceUid"].(string)
+			if uid, ok := datasourcesMap[name]; ok {
+				query["datasourceUid"] = uid
+			}
+		}
+
+		annotations, _ := rule["annotations"].(map[string]any)
+		if annotations == nil {
+			annotations = map[string]any{}
+			rule["annotations"] = annotations
+		}
+		if alertDef.RefPanelTitle != nil {
+			annotations["__dashboardUid__"] = live.UID
+			annotations["__panelId__"] = panelIDByTitle(live, *alertDef.RefPanelTitle)
+		} else {
+			annotations[customDashboardRefKey] = live.UID
+		}
+
+		desiredRules = append(desiredRules, rule)
+	}
+
+	return diffAlertRules(desiredRules, liveRules), nil
+}
+
+func diffAlertRules(desired []map[string]any, live []map[string]any) []Change {
+	var changes []Change
+
+	liveByTitle := make(map[string]map[string]any, len(live))
+	for _, rule := range live {
+		liveByTitle[strings.ToLower(fmt.Sprint(rule["title"]))] = rule
+	}
+
+	for _, rule := range desired {
+		title := fmt.Sprint(rule["title"])
+		liveRule, exists := liveByTitle[strings.ToLower(title)]
+		if !exists {
+			changes = append(changes, Change{Kind: Added, Resource: "alert rule", Name: title})
+			continue
+		}
+		delete(liveByTitle, strings.ToLower(title))
+
+		var fields []FieldChange
+		for _, key := range []string{"condition", "for", "noDataState", "execErrState", "labels", "annotations"} {
+			diffValues(key, rule[key], liveRule[key], false, &fields)
+		}
+		// Grafana enriches the queries models with defaults: only compare
+		// what we define.
+		diffValues("data", rule["data"], liveRule["data"], true, &fields)
+
+		if len(fields) != 0 {
+			changes = append(changes, Change{Kind: Changed, Resource: "alert rule", Name: title, Fields: fields})
+		}
+	}
+
+	for _, title := range sortedKeys(liveByTitle) {
+		changes = append(changes, Change{Kind: Removed, Resource: "alert rule", Name: fmt.Sprint(liveByTitle[title]["title"])})
+	}
+
+	return changes
+}
+
+func diffBoardSettings(desired *sdk.Board, live *sdk.Board) ([]FieldChange, error) {
+	desiredMap, err := normalizeForDiff(desired)
+	if err != nil {
+		return nil, err
+	}
+	liveMap, err := normalizeForDiff(live)
+	if err != nil {
+		return nil, err
+	}
+
+	ignored := []string{"id", "version", "schemaVersion", "rows", "panels", "templating"}
+	if desired.UID == "" {
+		ignored = append(ignored, "uid")
+	}
+
+	for _, key := range ignored {
+		delete(desiredMap, key)
+		delete(liveMap, key)
+	}
+
+	var fields []FieldChange
+	diffValues("", desiredMap, liveMap, false, &fields)
+
+	return fields, nil
+}
+
+func diffRows(desired *sdk.Board, live *sdk.Board) ([]Change, error) {
+	index := func(board *sdk.Board) (map[string]any, []string, error) {
+		rows := map[string]any{}
+		names := []string{}
+
+		for _, row := range board.Rows {
+			normalized, err := normalizeForDiff(row)
+			if err != nil {
+				return nil, nil, err
+			}
+			delete(normalized, "panels")
+
+			name := uniqueName(rows, row.Title)
+			rows[name] = normalized
+			names = append(names, name)
+		}
+
+		return rows, names, nil
+	}
+
+	return diffIndexed("row", desired, live, index)
+}
+
+func diffPanels(desired *sdk.Board, live *sdk.Board) ([]Change, error) {
+	index := func(board *sdk.Board) (map[string]any, []string, error) {
+		panels := map[string]any{}
+		names := []string{}
+
+		add := func(panel *sdk.Panel) error {
+			normalized, err := normalizeForDiff(panel)
+			if err != nil {
+				return err
+			}
+			delete(normalized, "id")
+
+			name := uniqueName(panels, panel.Title)
+			panels[name] = normalized
+			names = append(names, name)
+
+			return nil
+		}
+
+		for _, row := range board.Rows {
+			for i := range row.Panels {
+				if err := add(&row.Panels[i]); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+		for _, panel := range board.Panels {
+			if err := add(panel); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		return panels, names, nil
+	}
+
+	return diffIndexed("panel", desired, live, index)
+}
+
+func diffVariables(desired *sdk.Board, live *sdk.Board) ([]Change, error) {
+	index := func(board *sdk.Board) (map[string]any, []string, error) {
+		variables := map[string]any{}
+		names := []string{}
+
+		for _, variable := range board.Templating.List {
+			normalized, err := normalizeForDiff(variable)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			name := uniqueName(variables, variable.Name)
+			variables[name] = normalized
+			names = append(names, name)
+		}
+
+		return variables, names, nil
+	}
+
+	return diffIndexed("variable", desired, live, index)
+}
+
+type diffIndexer func(board *sdk.Board) (map[string]any, []string, error)
+
+func diffIndexed(resource string, desired *sdk.Board, live *sdk.Board, index diffIndexer) ([]Change, error) {
+	desiredItems, desiredNames, err := index(desired)
+	if err != nil {
+		return nil, err
+	}
+	liveItems, liveNames, err := index(live)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+
+	for _, name := range desiredNames {
+		liveItem, exists := liveItems[name]
+		if !exists {
+			changes = append(changes, Change{Kind: Added, Resource: resource, Name: name})
+			continue
+		}
+
+		var fields []FieldChange
+		diffValues("", desiredItems[name], liveItem, false, &fields)
+
+		if len(fields) != 0 {
+			changes = append(changes, Change{Kind: Changed, Resource: resource, Name: name, Fields: fields})
+		}
+	}
+
+	for _, name := range liveNames {
+		if _, exists := desiredItems[name]; !exists {
+			changes = append(changes, Change{Kind: Removed, Resource: resource, Name: name})
+		}
+	}
+
+	return changes, nil
+}
+
+// diffValues recursively compares two JSON-like values. When subset is true,
+// keys only present in the live value are ignored.
+func diffValues(path string, desired any, live any, subset bool, fields *[]FieldChange) {
+	desiredMap, desiredIsMap := desired.(map[string]any)
+	liveMap, liveIsMap := live.(map[string]any)
+	if desiredIsMap && liveIsMap {
+		keys := sortedKeys(desiredMap)
+		if !subset {
+			for key := range liveMap {
+				if _, exists := desiredMap[key]; !exists {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+		}
+
+		for _, key := range keys {
+			diffValues(joinDiffPath(path, key), desiredMap[key], liveMap[key], subset, fields)
+		}
+
+		return
+	}
+
+	desiredSlice, desiredIsSlice := desired.([]any)
+	liveSlice, liveIsSlice := live.([]any)
+	if desiredIsSlice && liveIsSlice && len(desiredSlice) == len(liveSlice) {
+		for i := range desiredSlice {
+			diffValues(fmt.Sprintf("%s[%d]", path, i), desiredSlice[i], liveSlice[i], subset, fields)
+		}
+
+		return
+	}
+
+	if isEmptyDiffValue(desired) && isEmptyDiffValue(live) {
+		return
+	}
+	if reflect.DeepEqual(desired, live) {
+		return
+	}
+
+	*fields = append(*fields, FieldChange{Path: path, Old: live, New: desired})
+}
+
+func normalizeForDiff(input any) (map[string]any, error) {
+	buf, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := map[string]any{}
+	if err := json.Unmarshal(buf, &normalized); err != nil {
+		return nil, err
+	}
+
+	return normalized, nil
+}
+
+func isEmptyDiffValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	}
+
+	return false
+}
+
+func joinDiffPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func uniqueName(existing map[string]any, name string) string {
+	candidate := name
+
+	for i := 2; ; i++ {
+		if _, exists := existing[candidate]; !exists {
+			return candidate
+		}
+
+		candidate = fmt.Sprintf("%s (%d)", name, i)
+	}
+}
+
+func sortedKeys[T any](input map[string]T) []string {
+	keys := make([]string, 0, len(input))
+	for key := range input {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func formatDiffValue(value any) string {
+	if value == nil {
+		return "<none>"
+	}
+
+	buf, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(buf)
+}
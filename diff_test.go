@@ -0,0 +1,135 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/K-Phoen/grabana/dashboard"
+	"github.com/K-Phoen/grabana/row"
+	"github.com/K-Phoen/grabana/text"
+	"github.com/K-Phoen/grabana/variable/custom"
+	"github.com/stretchr/testify/require"
+)
+
+func diffTestServer(t *testing.T, live dashboard.Builder, rules string) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/search", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `[{"id": 42, "uid": "live-uid", "title": %q}]`, live.Internal().Title)
+	})
+	mux.HandleFunc("/api/dashboards/uid/live-uid", func(w http.ResponseWriter, r *http.Request) {
+		board := live.Internal()
+		board.ID = 42
+		board.UID = "live-uid"
+		board.Version = 12
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"dashboard": board})
+	})
+	mux.HandleFunc("/api/v1/provisioning/alert-rules", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintln(w, rules)
+	})
+	mux.HandleFunc("/api/datasources", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintln(w, `[]`)
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestDiffDashboardWithNoChanges(t *testing.T) {
+	req := require.New(t)
+
+	build := func() dashboard.Builder {
+		builder, err := dashboard.New("Diff me",
+			dashboard.Tags([]string{"generated"}),
+			dashboard.Row("Row", row.WithText("Text", text.Markdown("hello"))),
+		)
+		req.NoError(err)
+
+		return builder
+	}
+
+	ts := diffTestServer(t, build(), `[]`)
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	diff, err := client.DiffDashboard(context.TODO(), &Folder{UID: "folder-uid"}, build())
+	req.NoError(err)
+
+	req.True(diff.Exists)
+	req.False(diff.HasChanges())
+	req.Empty(diff.Settings)
+	req.Empty(diff.Changes)
+}
+
+func TestDiffDashboardDetectsChanges(t *testing.T) {
+	req := require.New(t)
+
+	live, err := dashboard.New("Diff me",
+		dashboard.Tags([]string{"generated"}),
+		dashboard.VariableAsCustom("env", custom.Values(map[string]string{"prod": "prod"})),
+		dashboard.Row("Row",
+			row.WithText("Unchanged", text.Markdown("hello")),
+			row.WithText("Changed", text.Markdown("old")),
+			row.WithText("Removed", text.Markdown("bye")),
+		),
+	)
+	req.NoError(err)
+
+	desired, err := dashboard.New("Diff me",
+		dashboard.Tags([]string{"generated", "new-tag"}),
+		dashboard.Row("Row",
+			row.WithText("Unchanged", text.Markdown("hello")),
+			row.WithText("Changed", text.Markdown("new")),
+			row.WithText("Added", text.Markdown("hi")),
+		),
+	)
+	req.NoError(err)
+
+	rules := `[
+  {"uid": "stale", "title": "Stale alert", "folderUID": "folder-uid", "ruleGroup": "Diff me", "annotations": {"customDashboardRef": "live-uid"}},
+  {"uid": "other", "title": "Other alert", "folderUID": "folder-uid", "ruleGroup": "Other", "annotations": {"customDashboardRef": "other-uid"}}
+]`
+
+	ts := diffTestServer(t, live, rules)
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	diff, err := client.DiffDashboard(context.TODO(), &Folder{UID: "folder-uid"}, desired)
+	req.NoError(err)
+
+	req.True(diff.HasChanges())
+	req.Len(diff.Settings, 1)
+	req.Equal("tags", diff.Settings[0].Path)
+
+	req.Equal([]Change{
+		{Kind: Changed, Resource: "panel", Name: "Changed", Fields: []FieldChange{{Path: "content", Old: "old", New: "new"}}},
+		{Kind: Added, Resource: "panel", Name: "Added"},
+		{Kind: Removed, Resource: "panel", Name: "Removed"},
+		{Kind: Removed, Resource: "variable", Name: "env"},
+		{Kind: Removed, Resource: "alert rule", Name: "Stale alert"},
+	}, diff.Changes)
+}
+
+func TestDiffDashboardThatDoesNotExistYet(t *testing.T) {
+	req := require.New(t)
+
+	desired, err := dashboard.New("New one", dashboard.Row("Row", row.WithText("Text")))
+	req.NoError(err)
+
+	client := NewClient(http.DefaultClient, "http://unused")
+
+	diff, err := client.DiffDashboard(context.TODO(), nil, desired)
+	req.NoError(err)
+
+	req.False(diff.Exists)
+	req.True(diff.HasChanges())
+	req.Contains(diff.String(), `+ dashboard "New one"`)
+	req.Contains(diff.String(), `+ panel "Text"`)
+}
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 )
 
@@ -18,8 +20,19 @@ type Folder struct {
 	ID    uint   `json:"id"`
 	UID   string `json:"uid"`
 	Title string `json:"title"`
+	// ParentUID is empty for top-level folders.
+	ParentUID string `json:"parentUid,omitempty"`
 }
 
+// FolderTree describes a folder and all its descendants.
+type FolderTree struct {
+	Folder
+	Children []FolderTree
+}
+
+// folderPathSeparator separates the titles of the folders in a folder path.
+const folderPathSeparator = "/"
+
 // FindOrCreateFolder returns the folder by its name or creates it if it doesn't exist.
 func (client *Client) FindOrCreateFolder(ctx context.Context, name string) (*Folder, error) {
 	folder, err := client.GetFolderByTitle(ctx, name)
@@ -39,10 +52,23 @@ func (client *Client) FindOrCreateFolder(ctx context.Context, name string) (*Fol
 // CreateFolder creates a dashboard folder.
 // See https://grafana.com/docs/grafana/latest/reference/dashboard_folders/
 func (client *Client) CreateFolder(ctx context.Context, name string) (*Folder, error) {
+	return client.createFolder(ctx, name, "")
+}
+
+// CreateChildFolder creates a dashboard folder in the given parent folder.
+// Nested folders must be enabled in Grafana.
+// See https://grafana.com/docs/grafana/latest/dashboards/manage-dashboards/#folders
+func (client *Client) CreateChildFolder(ctx context.Context, parentUID string, name string) (*Folder, error) {
+	return client.createFolder(ctx, name, parentUID)
+}
+
+func (client *Client) createFolder(ctx context.Context, name string, parentUID string) (*Folder, error) {
 	buf, err := json.Marshal(struct {
-		Title string `json:"title"`
+		Title     string `json:"title"`
+		ParentUID string `json:"parentUid,omitempty"`
 	}{
-		Title: name,
+		Title:     name,
+		ParentUID: parentUID,
 	})
 	if err != nil {
 		return nil, err
@@ -67,15 +93,52 @@ func (client *Client) CreateFolder(ctx context.Context, name string) (*Folder, e
 	return &folder, nil
 }
 
-// ListFolders returns all folders.
+// foldersPerPage is the number of folders fetched per page.
+const foldersPerPage = 1000
+
+// ListFolders returns all folders. When nested folders are enabled, only
+// top-level folders are returned. Folders are fetched page by page until
+// every one of them is retrieved.
 func (client *Client) ListFolders(ctx context.Context) ([]Folder, error) {
-	resp, err := client.get(ctx, "/api/folders?limit=1000")
+	return client.listFolders(ctx, url.Values{})
+}
+
+// ListChildFolders returns the direct children of the given folder.
+func (client *Client) ListChildFolders(ctx context.Context, parentUID string) ([]Folder, error) {
+	return client.listFolders(ctx, url.Values{"parentUid": []string{parentUID}})
+}
+
+func (client *Client) listFolders(ctx context.Context, values url.Values) ([]Folder, error) {
+	folders := []Folder{}
+
+	for page := 1; ; page++ {
+		values.Set("limit", strconv.Itoa(foldersPerPage))
+		values.Set("page", strconv.Itoa(page))
+
+		results, err := client.listFoldersPage(ctx, values)
+		if err != nil {
+			return nil, err
+		}
+
+		folders = append(folders, results...)
+
+		if len(results) < foldersPerPage {
+			return folders, nil
+		}
+	}
+}
+
+func (client *Client) listFoldersPage(ctx context.Context, values url.Values) ([]Folder, error) {
+	resp, err := client.get(ctx, "/api/folders?"+values.Encode())
 	if err != nil {
 		return nil, err
 	}
 
 	defer func() { _ = resp.Body.Close() }()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrFolderNotFound
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, client.httpError(resp)
 	}
@@ -87,6 +150,191 @@ func (client *Client) ListFolders(ctx context.Context) ([]Folder, error) {
 	return folders, nil
 }
 
+// GetFolderByUID finds a folder, given its UID.
+func (client *Client) GetFolderByUID(ctx context.Context, uid string) (*Folder, error) {
+	resp, err := client.get(ctx, "/api/folders/"+url.PathEscape(uid))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrFolderNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var folder Folder
+	if err := decodeJSON(resp.Body, &folder); err != nil {
+		return nil, err
+	}
+
+	return &folder, nil
+}
+
+// GetFolderByPath finds a folder, given its path: the titles of the folders
+// leading to it, separated by slashes. Example: "Platform/Databases/Postgres".
+func (client *Client) GetFolderByPath(ctx context.Context, path string) (*Folder, error) {
+	return client.walkFolderPath(ctx, nil, path, false)
+}
+
+// EnsureFolderPath finds a folder given its path, creating the missing
+// folders along the way. Example: "Platform/Databases/Postgres".
+func (client *Client) EnsureFolderPath(ctx context.Context, path string) (*Folder, error) {
+	return client.walkFolderPath(ctx, nil, path, true)
+}
+
+// GetChildFolderByPath finds a folder, given its path relative to the given
+// parent folder. A nil parent behaves like GetFolderByPath.
+func (client *Client) GetChildFolderByPath(ctx context.Context, parent *Folder, path string) (*Folder, error) {
+	return client.walkFolderPath(ctx, parent, path, false)
+}
+
+// EnsureChildFolderPath finds a folder given its path relative to the given
+// parent folder, creating the missing folders along the way. A nil parent
+// behaves like EnsureFolderPath.
+func (client *Client) EnsureChildFolderPath(ctx context.Context, parent *Folder, path string) (*Folder, error) {
+	return client.walkFolderPath(ctx, parent, path, true)
+}
+
+func (client *Client) walkFolderPath(ctx context.Context, parent *Folder, path string, create bool) (*Folder, error) {
+	titles := splitFolderPath(path)
+	if len(titles) == 0 {
+		return nil, fmt.Errorf("invalid folder path '%s'", path)
+	}
+
+	current := parent
+	for _, title := range titles {
+		var parentUID string
+		var children []Folder
+		var err error
+		if current == nil {
+			children, err = client.ListFolders(ctx)
+		} else {
+			parentUID = current.UID
+			children, err = client.ListChildFolders(ctx, parentUID)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		next := findFolderByTitle(children, title, parentUID)
+		if next == nil && !create {
+			return nil, ErrFolderNotFound
+		}
+		if next == nil {
+			next, err = client.createFolder(ctx, title, parentUID)
+			if err != nil {
+				return nil, fmt.Errorf("could not create folder '%s': %w", title, err)
+			}
+		}
+
+		current = next
+	}
+
+	return current, nil
+}
+
+func splitFolderPath(path string) []string {
+	var titles []string
+	for _, title := range strings.Split(path, folderPathSeparator) {
+		title = strings.TrimSpace(title)
+		if title != "" {
+			titles = append(titles, title)
+		}
+	}
+
+	return titles
+}
+
+func findFolderByTitle(folders []Folder, title string, parentUID string) *Folder {
+	for i := range folders {
+		// without nested folders support, Grafana lists every folder
+		if folders[i].ParentUID != parentUID {
+			continue
+		}
+
+		if strings.EqualFold(folders[i].Title, title) {
+			return &folders[i]
+		}
+	}
+
+	return nil
+}
+
+// MoveFolder moves a folder under a new parent. An empty parentUID moves the
+// folder to the top-level.
+func (client *Client) MoveFolder(ctx context.Context, uid string, parentUID string) (*Folder, error) {
+	buf, err := json.Marshal(struct {
+		ParentUID string `json:"parentUid"`
+	}{
+		ParentUID: parentUID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPost, "/api/folders/"+url.PathEscape(uid)+"/move", buf)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrFolderNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var folder Folder
+	if err := decodeJSON(resp.Body, &folder); err != nil {
+		return nil, err
+	}
+
+	return &folder, nil
+}
+
+// GetFolderTree returns the given folder along with all its descendants.
+func (client *Client) GetFolderTree(ctx context.Context, uid string) (*FolderTree, error) {
+	folder, err := client.GetFolderByUID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := &FolderTree{Folder: *folder}
+	if err := client.fillFolderTree(ctx, tree); err != nil {
+		return nil, err
+	}
+
+	return tree, nil
+}
+
+func (client *Client) fillFolderTree(ctx context.Context, tree *FolderTree) error {
+	children, err := client.ListChildFolders(ctx, tree.UID)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		if child.ParentUID != tree.UID {
+			continue
+		}
+
+		subtree := FolderTree{Folder: child}
+		if err := client.fillFolderTree(ctx, &subtree); err != nil {
+			return err
+		}
+
+		tree.Children = append(tree.Children, subtree)
+	}
+
+	return nil
+}
+
 // GetFolderByTitle finds a folder, given its title.
 func (client *Client) GetFolderByTitle(ctx context.Context, title string) (*Folder, error) {
 	folders, err := client.ListFolders(ctx)
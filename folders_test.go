@@ -2,6 +2,7 @@ package grabana
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -192,3 +193,154 @@ func TestGetFolderByTitleCanFail(t *testing.T) {
 	req.Error(err)
 	req.Nil(folder)
 }
+
+func TestChildFoldersCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	var payload map[string]string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodPost, r.Method)
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+
+		_, _ = fmt.Fprintln(w, `{"id": 2, "uid": "child-uid", "title": "Child", "parentUid": "parent-uid"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	folder, err := client.CreateChildFolder(context.TODO(), "parent-uid", "Child")
+
+	req.NoError(err)
+	req.Equal(map[string]string{"title": "Child", "parentUid": "parent-uid"}, payload)
+	req.Equal("parent-uid", folder.ParentUID)
+}
+
+func TestAFolderCanBeFoundByPath(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("parentUid") {
+		case "":
+			_, _ = fmt.Fprintln(w, `[{"id": 1, "uid": "platform", "title": "Platform"}, {"id": 2, "uid": "other", "title": "Other"}]`)
+		case "platform":
+			_, _ = fmt.Fprintln(w, `[{"id": 3, "uid": "databases", "title": "Databases", "parentUid": "platform"}]`)
+		default:
+			_, _ = fmt.Fprintln(w, `[]`)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	folder, err := client.GetFolderByPath(context.TODO(), "Platform/databases")
+	req.NoError(err)
+	req.Equal("databases", folder.UID)
+
+	_, err = client.GetFolderByPath(context.TODO(), "Platform/Databases/Postgres")
+	req.ErrorIs(err, ErrFolderNotFound)
+}
+
+func TestFolderPathsListTopLevelFoldersOnlyOnce(t *testing.T) {
+	req := require.New(t)
+
+	var requestedParents []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedParents = append(requestedParents, r.URL.Query().Get("parentUid"))
+
+		switch r.URL.Query().Get("parentUid") {
+		case "":
+			_, _ = fmt.Fprintln(w, `[{"id": 1, "uid": "platform", "title": "Platform"}]`)
+		case "platform":
+			_, _ = fmt.Fprintln(w, `[{"id": 2, "uid": "databases", "title": "Databases", "parentUid": "platform"}]`)
+		default:
+			_, _ = fmt.Fprintln(w, `[]`)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.GetFolderByPath(context.TODO(), "Platform/Databases")
+
+	req.NoError(err)
+	req.Equal([]string{"", "platform"}, requestedParents)
+}
+
+func TestFoldersAreListedPageByPage(t *testing.T) {
+	req := require.New(t)
+
+	var requestedPages []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("1000", r.URL.Query().Get("limit"))
+		requestedPages = append(requestedPages, r.URL.Query().Get("page"))
+
+		if r.URL.Query().Get("page") != "1" {
+			_, _ = fmt.Fprintln(w, `[{"id": 1001, "uid": "last", "title": "Last"}]`)
+			return
+		}
+
+		folders := make([]Folder, 0, 1000)
+		for i := 0; i < 1000; i++ {
+			folders = append(folders, Folder{ID: uint(i + 1), UID: fmt.Sprintf("folder-%d", i), Title: fmt.Sprintf("Folder %d", i)})
+		}
+		req.NoError(json.NewEncoder(w).Encode(folders))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	folders, err := client.ListFolders(context.TODO())
+
+	req.NoError(err)
+	req.Len(folders, 1001)
+	req.Equal([]string{"1", "2"}, requestedPages)
+
+	folder, err := client.GetFolderByTitle(context.TODO(), "Last")
+	req.NoError(err)
+	req.Equal("last", folder.UID)
+}
+
+func TestAnEmptyFolderPathIsRejected(t *testing.T) {
+	req := require.New(t)
+
+	client := NewClient(http.DefaultClient, "http://localhost")
+
+	_, err := client.EnsureFolderPath(context.TODO(), " / ")
+
+	req.Error(err)
+}
+
+func TestFoldersCanBeMoved(t *testing.T) {
+	req := require.New(t)
+
+	var payload map[string]string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodPost, r.Method)
+		req.Equal("/api/folders/child-uid/move", r.URL.Path)
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+
+		_, _ = fmt.Fprintln(w, `{"id": 2, "uid": "child-uid", "title": "Child", "parentUid": "new-parent"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	folder, err := client.MoveFolder(context.TODO(), "child-uid", "new-parent")
+
+	req.NoError(err)
+	req.Equal(map[string]string{"parentUid": "new-parent"}, payload)
+	req.Equal("new-parent", folder.ParentUID)
+}
+
+func TestMovingAnUnknownFolderFailsCleanly(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.MoveFolder(context.TODO(), "unknown", "")
+
+	req.ErrorIs(err, ErrFolderNotFound)
+}
@@ -0,0 +1,473 @@
+package grabanatest
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+)
+
+// defaultContactPoint is the contact point that Grafana creates with every
+// organization, and that the default notification policy uses.
+const defaultContactPoint = "grafana-default-email"
+
+// ContactPoint is a contact point integration stored by the fake server.
+type ContactPoint struct {
+	UID                   string                 `json:"uid"`
+	Name                  string                 `json:"name"`
+	Type                  string                 `json:"type"`
+	Settings              map[string]interface{} `json:"settings"`
+	DisableResolveMessage bool                   `json:"disableResolveMessage"`
+}
+
+// ContactPoints returns a copy of the contact point integrations currently
+// stored.
+func (server *Server) ContactPoints() []ContactPoint {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	contactPoints := make([]ContactPoint, 0, len(server.contactPoints))
+	for _, contactPoint := range server.contactPoints {
+		contactPoints = append(contactPoints, *contactPoint)
+	}
+
+	return contactPoints
+}
+
+// NotificationPolicies returns the notification policy tree currently
+// configured.
+func (server *Server) NotificationPolicies() json.RawMessage {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	return server.notificationPolicies
+}
+
+// MuteTiming is a mute timing stored by the fake server.
+type MuteTiming struct {
+	Name string
+	// Model is the JSON model of the mute timing, as last saved.
+	Model json.RawMessage
+}
+
+// MuteTimings returns a copy of the mute timings currently stored.
+func (server *Server) MuteTimings() []MuteTiming {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	timings := make([]MuteTiming, 0, len(server.muteTimings))
+	for _, timing := range server.muteTimings {
+		timings = append(timings, *timing)
+	}
+
+	return timings
+}
+
+// MessageTemplates returns a copy of the message templates currently
+// stored, indexed by name.
+func (server *Server) MessageTemplates() map[string]string {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	templates := make(map[string]string, len(server.messageTemplates))
+	for name, template := range server.messageTemplates {
+		templates[name] = template
+	}
+
+	return templates
+}
+
+func (server *Server) registerAlertingRoutes() {
+	server.handle(http.MethodGet, "/api/v1/provisioning/contact-points", server.listContactPoints)
+	server.handle(http.MethodPost, "/api/v1/provisioning/contact-points", server.postContactPoint)
+	server.handle(http.MethodPut, "/api/v1/provisioning/contact-points/{uid}", server.putContactPoint)
+	server.handle(http.MethodDelete, "/api/v1/provisioning/contact-points/{uid}", server.deleteContactPoint)
+
+	server.handle(http.MethodGet, "/api/v1/provisioning/policies", server.getNotificationPolicies)
+	server.handle(http.MethodPut, "/api/v1/provisioning/policies", server.putNotificationPolicies)
+	server.handle(http.MethodDelete, "/api/v1/provisioning/policies", server.resetNotificationPolicies)
+
+	server.handle(http.MethodGet, "/api/v1/provisioning/templates", server.listMessageTemplates)
+	server.handle(http.MethodGet, "/api/v1/provisioning/templates/{name}", server.getMessageTemplate)
+	server.handle(http.MethodPut, "/api/v1/provisioning/templates/{name}", server.putMessageTemplate)
+	server.handle(http.MethodDelete, "/api/v1/provisioning/templates/{name}", server.deleteMessageTemplate)
+
+	server.handle(http.MethodGet, "/api/v1/provisioning/mute-timings", server.listMuteTimings)
+	server.handle(http.MethodPost, "/api/v1/provisioning/mute-timings", server.postMuteTiming)
+	server.handle(http.MethodGet, "/api/v1/provisioning/mute-timings/{name}", server.getMuteTiming)
+	server.handle(http.MethodPut, "/api/v1/provisioning/mute-timings/{name}", server.putMuteTiming)
+	server.handle(http.MethodDelete, "/api/v1/provisioning/mute-timings/{name}", server.deleteMuteTiming)
+}
+
+// seedAlerting creates the alerting resources that Grafana provisions by
+// default.
+func (server *Server) seedAlerting() {
+	server.contactPoints = []*ContactPoint{
+		{
+			UID:      server.generateUID("contact-point"),
+			Name:     defaultContactPoint,
+			Type:     "email",
+			Settings: map[string]interface{}{"addresses": "<example@email.com>"},
+		},
+	}
+	server.notificationPolicies = defaultNotificationPolicies()
+	server.messageTemplates = map[string]string{}
+}
+
+func defaultNotificationPolicies() json.RawMessage {
+	policies, _ := json.Marshal(map[string]interface{}{
+		"receiver": defaultContactPoint,
+		"group_by": []string{"grafana_folder", "alertname"},
+	})
+
+	return policies
+}
+
+// policyNode is a node of a notification policy tree, restricted to the
+// fields referencing other alerting resources.
+type policyNode struct {
+	Receiver            string       `json:"receiver"`
+	MuteTimeIntervals   []string     `json:"mute_time_intervals"`
+	ActiveTimeIntervals []string     `json:"active_time_intervals"`
+	Routes              []policyNode `json:"routes"`
+}
+
+// policyReferences lists the contact points and the mute timings referenced
+// by a notification policy tree.
+func policyReferences(policies json.RawMessage) (map[string]bool, map[string]bool) {
+	root := policyNode{}
+	_ = json.Unmarshal(policies, &root)
+
+	receivers := map[string]bool{}
+	timings := map[string]bool{}
+
+	var walk func(node policyNode)
+	walk = func(node policyNode) {
+		if node.Receiver != "" {
+			receivers[node.Receiver] = true
+		}
+		for _, name := range append(node.MuteTimeIntervals, node.ActiveTimeIntervals...) {
+			timings[name] = true
+		}
+		for _, route := range node.Routes {
+			walk(route)
+		}
+	}
+	walk(root)
+
+	return receivers, timings
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (server *Server) contactPointByUID(uid string) *ContactPoint {
+	for _, contactPoint := range server.contactPoints {
+		if contactPoint.UID == uid {
+			return contactPoint
+		}
+	}
+
+	return nil
+}
+
+func (server *Server) contactPointExists(name string) bool {
+	for _, contactPoint := range server.contactPoints {
+		if contactPoint.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (server *Server) listContactPoints(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	name := r.URL.Query().Get("name")
+
+	contactPoints := []ContactPoint{}
+	for _, contactPoint := range server.contactPoints {
+		if name != "" && contactPoint.Name != name {
+			continue
+		}
+
+		contactPoints = append(contactPoints, *contactPoint)
+	}
+
+	writeJSON(w, http.StatusOK, contactPoints)
+}
+
+func (server *Server) postContactPoint(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	contactPoint := &ContactPoint{}
+	if !decodeBody(w, r, contactPoint) {
+		return
+	}
+
+	if contactPoint.Name == "" || contactPoint.Type == "" {
+		writeError(w, http.StatusBadRequest, "contact point name and type are required")
+		return
+	}
+	if contactPoint.UID == "" {
+		contactPoint.UID = server.generateUID("contact-point")
+	}
+	if server.contactPointByUID(contactPoint.UID) != nil {
+		writeError(w, http.StatusBadRequest, "contact point with the same uid already exists")
+		return
+	}
+
+	server.contactPoints = append(server.contactPoints, contactPoint)
+
+	writeJSON(w, http.StatusAccepted, contactPoint)
+}
+
+func (server *Server) putContactPoint(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	contactPoint := server.contactPointByUID(params["uid"])
+	if contactPoint == nil {
+		writeError(w, http.StatusNotFound, "contact point not found")
+		return
+	}
+
+	request := ContactPoint{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	contactPoint.Name = request.Name
+	contactPoint.Type = request.Type
+	contactPoint.Settings = request.Settings
+	contactPoint.DisableResolveMessage = request.DisableResolveMessage
+
+	writeJSON(w, http.StatusAccepted, map[string]string{"message": "contactpoint updated"})
+}
+
+func (server *Server) deleteContactPoint(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	contactPoint := server.contactPointByUID(params["uid"])
+	if contactPoint == nil {
+		writeError(w, http.StatusNotFound, "contact point not found")
+		return
+	}
+
+	contactPoints := make([]*ContactPoint, 0, len(server.contactPoints))
+	for _, candidate := range server.contactPoints {
+		if candidate != contactPoint {
+			contactPoints = append(contactPoints, candidate)
+		}
+	}
+
+	// the last integration of a contact point can not be removed while
+	// notification policies still route alerts to it
+	stillExists := false
+	for _, candidate := range contactPoints {
+		stillExists = stillExists || candidate.Name == contactPoint.Name
+	}
+	receivers, _ := policyReferences(server.notificationPolicies)
+	if !stillExists && receivers[contactPoint.Name] {
+		writeError(w, http.StatusConflict, "contact point '"+contactPoint.Name+"' is currently used by a notification policy")
+		return
+	}
+
+	server.contactPoints = contactPoints
+
+	writeJSON(w, http.StatusAccepted, map[string]string{"message": "contactpoint deleted"})
+}
+
+func (server *Server) getNotificationPolicies(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	writeJSON(w, http.StatusOK, server.notificationPolicies)
+}
+
+func (server *Server) putNotificationPolicies(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	policies := json.RawMessage{}
+	if !decodeBody(w, r, &policies) {
+		return
+	}
+
+	receivers, timings := policyReferences(policies)
+	if len(receivers) == 0 {
+		writeError(w, http.StatusBadRequest, "invalid object specification: root route must specify a default receiver")
+		return
+	}
+
+	for _, receiver := range sortedKeys(receivers) {
+		if !server.contactPointExists(receiver) {
+			writeError(w, http.StatusBadRequest, "invalid object specification: receiver '"+receiver+"' does not exist")
+			return
+		}
+	}
+	for _, timing := range sortedKeys(timings) {
+		if server.muteTimingByName(timing) == nil {
+			writeError(w, http.StatusBadRequest, "invalid object specification: mute time interval '"+timing+"' does not exist")
+			return
+		}
+	}
+
+	server.notificationPolicies = policies
+
+	writeJSON(w, http.StatusAccepted, map[string]string{"message": "policies updated"})
+}
+
+func (server *Server) resetNotificationPolicies(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	server.notificationPolicies = defaultNotificationPolicies()
+
+	writeJSON(w, http.StatusAccepted, map[string]string{"message": "policies reset"})
+}
+
+func (server *Server) listMessageTemplates(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	names := make([]string, 0, len(server.messageTemplates))
+	for name := range server.messageTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	templates := make([]map[string]string, 0, len(names))
+	for _, name := range names {
+		templates = append(templates, map[string]string{"name": name, "template": server.messageTemplates[name]})
+	}
+
+	writeJSON(w, http.StatusOK, templates)
+}
+
+func (server *Server) getMessageTemplate(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	template, ok := server.messageTemplates[params["name"]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "template not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"name": params["name"], "template": template})
+}
+
+func (server *Server) putMessageTemplate(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	request := struct {
+		Template string `json:"template"`
+	}{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	server.messageTemplates[params["name"]] = request.Template
+
+	writeJSON(w, http.StatusAccepted, map[string]string{"name": params["name"], "template": request.Template})
+}
+
+func (server *Server) deleteMessageTemplate(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	if _, ok := server.messageTemplates[params["name"]]; !ok {
+		writeError(w, http.StatusNotFound, "template not found")
+		return
+	}
+
+	delete(server.messageTemplates, params["name"])
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (server *Server) muteTimingByName(name string) *MuteTiming {
+	for _, timing := range server.muteTimings {
+		if timing.Name == name {
+			return timing
+		}
+	}
+
+	return nil
+}
+
+func decodeMuteTiming(w http.ResponseWriter, r *http.Request) (*MuteTiming, bool) {
+	model := json.RawMessage{}
+	if !decodeBody(w, r, &model) {
+		return nil, false
+	}
+
+	request := struct {
+		Name string `json:"name"`
+	}{}
+	_ = json.Unmarshal(model, &request)
+	if request.Name == "" {
+		writeError(w, http.StatusBadRequest, "invalid mute timing: name must not be empty")
+		return nil, false
+	}
+
+	return &MuteTiming{Name: request.Name, Model: model}, true
+}
+
+func (server *Server) listMuteTimings(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	timings := make([]json.RawMessage, 0, len(server.muteTimings))
+	for _, timing := range server.muteTimings {
+		timings = append(timings, timing.Model)
+	}
+
+	writeJSON(w, http.StatusOK, timings)
+}
+
+func (server *Server) postMuteTiming(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	timing, ok := decodeMuteTiming(w, r)
+	if !ok {
+		return
+	}
+
+	if server.muteTimingByName(timing.Name) != nil {
+		writeError(w, http.StatusConflict, "mute timing '"+timing.Name+"' already exists")
+		return
+	}
+
+	server.muteTimings = append(server.muteTimings, timing)
+
+	writeJSON(w, http.StatusCreated, timing.Model)
+}
+
+func (server *Server) getMuteTiming(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	timing := server.muteTimingByName(params["name"])
+	if timing == nil {
+		writeError(w, http.StatusNotFound, "mute timing not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, timing.Model)
+}
+
+func (server *Server) putMuteTiming(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	timing := server.muteTimingByName(params["name"])
+	if timing == nil {
+		writeError(w, http.StatusNotFound, "mute timing not found")
+		return
+	}
+
+	updated, ok := decodeMuteTiming(w, r)
+	if !ok {
+		return
+	}
+	if updated.Name != timing.Name {
+		writeError(w, http.StatusBadRequest, "mute timings can not be renamed")
+		return
+	}
+
+	timing.Model = updated.Model
+
+	writeJSON(w, http.StatusAccepted, timing.Model)
+}
+
+func (server *Server) deleteMuteTiming(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	timing := server.muteTimingByName(params["name"])
+	if timing == nil {
+		writeError(w, http.StatusNotFound, "mute timing not found")
+		return
+	}
+
+	if _, timings := policyReferences(server.notificationPolicies); timings[timing.Name] {
+		writeError(w, http.StatusConflict, "mute timing '"+timing.Name+"' is currently used by a notification policy")
+		return
+	}
+
+	timings := make([]*MuteTiming, 0, len(server.muteTimings))
+	for _, candidate := range server.muteTimings {
+		if candidate != timing {
+			timings = append(timings, candidate)
+		}
+	}
+	server.muteTimings = timings
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,208 @@
+package grabanatest
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// AlertRule is a provisioned alert rule stored by the fake server.
+type AlertRule struct {
+	UID         string
+	Title       string
+	FolderUID   string
+	RuleGroup   string
+	Labels      map[string]string
+	Annotations map[string]string
+	// Model is the JSON model of the rule, as last saved.
+	Model json.RawMessage
+}
+
+// AlertRules returns a copy of the alert rules currently stored.
+func (server *Server) AlertRules() []AlertRule {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	rules := make([]AlertRule, 0, len(server.alertRules))
+	for _, model := range server.alertRules {
+		rules = append(rules, alertRuleFromModel(model))
+	}
+
+	return rules
+}
+
+// AlertManagerConfig returns the last alertmanager configuration that was
+// pushed, or nil.
+func (server *Server) AlertManagerConfig() json.RawMessage {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	return server.alertManagerConfig
+}
+
+func (server *Server) registerAlertRoutes() {
+	server.handle(http.MethodGet, "/api/v1/provisioning/alert-rules", server.listAlertRules)
+	server.handle(http.MethodPost, "/api/v1/provisioning/alert-rules", server.postAlertRule)
+	server.handle(http.MethodGet, "/api/v1/provisioning/alert-rules/{uid}", server.getAlertRule)
+	server.handle(http.MethodPut, "/api/v1/provisioning/alert-rules/{uid}", server.putAlertRule)
+	server.handle(http.MethodDelete, "/api/v1/provisioning/alert-rules/{uid}", server.deleteAlertRule)
+
+	server.handle(http.MethodGet, "/api/alertmanager/grafana/config/api/v1/alerts", server.getAlertManagerConfig)
+	server.handle(http.MethodPost, "/api/alertmanager/grafana/config/api/v1/alerts", server.postAlertManagerConfig)
+}
+
+func alertRuleFromModel(model json.RawMessage) AlertRule {
+	rule := struct {
+		UID         string            `json:"uid"`
+		Title       string            `json:"title"`
+		FolderUID   string            `json:"folderUID"`
+		RuleGroup   string            `json:"ruleGroup"`
+		Labels      map[string]string `json:"labels"`
+		Annotations map[string]string `json:"annotations"`
+	}{}
+	_ = json.Unmarshal(model, &rule)
+
+	return AlertRule{
+		UID:         rule.UID,
+		Title:       rule.Title,
+		FolderUID:   rule.FolderUID,
+		RuleGroup:   rule.RuleGroup,
+		Labels:      rule.Labels,
+		Annotations: rule.Annotations,
+		Model:       model,
+	}
+}
+
+func (server *Server) alertRuleIndex(uid string) int {
+	for i, model := range server.alertRules {
+		if alertRuleFromModel(model).UID == uid {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (server *Server) alertRulesInFolder(folderUID string) []string {
+	var uids []string
+	for _, model := range server.alertRules {
+		rule := alertRuleFromModel(model)
+		if rule.FolderUID == folderUID {
+			uids = append(uids, rule.UID)
+		}
+	}
+
+	return uids
+}
+
+func (server *Server) removeAlertRule(uid string) {
+	index := server.alertRuleIndex(uid)
+	if index == -1 {
+		return
+	}
+
+	server.alertRules = append(server.alertRules[:index], server.alertRules[index+1:]...)
+}
+
+// decodeAlertRule validates an alert rule sent to the server and returns its
+// normalized model.
+func (server *Server) decodeAlertRule(w http.ResponseWriter, r *http.Request, uid string) (json.RawMessage, bool) {
+	payload := map[string]interface{}{}
+	if !decodeBody(w, r, &payload) {
+		return nil, false
+	}
+
+	title, _ := payload["title"].(string)
+	folderUID, _ := payload["folderUID"].(string)
+	ruleGroup, _ := payload["ruleGroup"].(string)
+
+	if title == "" || ruleGroup == "" {
+		writeError(w, http.StatusBadRequest, "invalid alert rule: title and rule group are required")
+		return nil, false
+	}
+	if server.folderByUID(folderUID) == nil {
+		writeError(w, http.StatusBadRequest, "invalid alert rule: folder does not exist")
+		return nil, false
+	}
+
+	payload["uid"] = uid
+
+	model, err := json.Marshal(payload)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+
+	return model, true
+}
+
+func (server *Server) listAlertRules(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	rules := make([]json.RawMessage, 0, len(server.alertRules))
+	rules = append(rules, server.alertRules...)
+
+	writeJSON(w, http.StatusOK, rules)
+}
+
+func (server *Server) getAlertRule(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	index := server.alertRuleIndex(params["uid"])
+	if index == -1 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, server.alertRules[index])
+}
+
+func (server *Server) postAlertRule(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	model, ok := server.decodeAlertRule(w, r, server.generateUID("alert"))
+	if !ok {
+		return
+	}
+
+	server.alertRules = append(server.alertRules, model)
+
+	writeJSON(w, http.StatusCreated, model)
+}
+
+func (server *Server) putAlertRule(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	index := server.alertRuleIndex(params["uid"])
+	if index == -1 {
+		writeError(w, http.StatusNotFound, "rule not found")
+		return
+	}
+
+	model, ok := server.decodeAlertRule(w, r, params["uid"])
+	if !ok {
+		return
+	}
+
+	server.alertRules[index] = model
+
+	writeJSON(w, http.StatusOK, model)
+}
+
+func (server *Server) deleteAlertRule(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	// Grafana answers with a 204 even when the rule does not exist.
+	server.removeAlertRule(params["uid"])
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (server *Server) getAlertManagerConfig(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	if server.alertManagerConfig == nil {
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, server.alertManagerConfig)
+}
+
+func (server *Server) postAlertManagerConfig(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	config := json.RawMessage{}
+	if !decodeBody(w, r, &config) {
+		return
+	}
+
+	server.alertManagerConfig = config
+
+	writeJSON(w, http.StatusAccepted, map[string]string{"message": "configuration created"})
+}
@@ -0,0 +1,193 @@
+package grabanatest
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Annotation is an annotation stored by the fake server.
+type Annotation struct {
+	ID           int      `json:"id"`
+	DashboardUID string   `json:"dashboardUID,omitempty"`
+	PanelID      int      `json:"panelId,omitempty"`
+	Time         int64    `json:"time"`
+	TimeEnd      int64    `json:"timeEnd"`
+	Tags         []string `json:"tags"`
+	Text         string   `json:"text"`
+	Login        string   `json:"login"`
+}
+
+// Annotations returns a copy of the annotations currently stored.
+func (server *Server) Annotations() []Annotation {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	annotations := make([]Annotation, 0, len(server.annotations))
+	for _, annotation := range server.annotations {
+		annotations = append(annotations, *annotation)
+	}
+
+	return annotations
+}
+
+func (server *Server) registerAnnotationRoutes() {
+	server.handle(http.MethodGet, "/api/annotations", server.listAnnotations)
+	server.handle(http.MethodPost, "/api/annotations", server.postAnnotation)
+	server.handle(http.MethodPut, "/api/annotations/{id}", server.putAnnotation)
+	server.handle(http.MethodDelete, "/api/annotations/{id}", server.deleteAnnotation)
+}
+
+func (server *Server) annotationByID(idParam string) *Annotation {
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		return nil
+	}
+
+	for _, annotation := range server.annotations {
+		if annotation.ID == id {
+			return annotation
+		}
+	}
+
+	return nil
+}
+
+func (server *Server) decodeAnnotation(w http.ResponseWriter, r *http.Request, annotation *Annotation) bool {
+	if !decodeBody(w, r, annotation) {
+		return false
+	}
+
+	if annotation.DashboardUID != "" && server.dashboardByUID(annotation.DashboardUID) == nil {
+		writeError(w, http.StatusNotFound, "Dashboard not found")
+		return false
+	}
+
+	if annotation.Time == 0 {
+		annotation.Time = time.Now().UnixMilli()
+	}
+	if annotation.TimeEnd == 0 {
+		annotation.TimeEnd = annotation.Time
+	}
+	if annotation.Tags == nil {
+		annotation.Tags = []string{}
+	}
+	annotation.Login = server.currentLogin
+
+	return true
+}
+
+func (server *Server) listAnnotations(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	query := r.URL.Query()
+
+	from, _ := strconv.ParseInt(query.Get("from"), 10, 64)
+	to, _ := strconv.ParseInt(query.Get("to"), 10, 64)
+	panelID, _ := strconv.Atoi(query.Get("panelId"))
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 100
+	}
+
+	matchesTags := func(annotation *Annotation) bool {
+		tags := query["tags"]
+		if len(tags) == 0 {
+			return true
+		}
+
+		matchAny := query.Get("matchAny") == "true"
+		for _, tag := range tags {
+			found := containsString(annotation.Tags, tag)
+			if found && matchAny {
+				return true
+			}
+			if !found && !matchAny {
+				return false
+			}
+		}
+
+		return !matchAny
+	}
+
+	annotations := []Annotation{}
+	for _, annotation := range server.annotations {
+		if uid := query.Get("dashboardUID"); uid != "" && annotation.DashboardUID != uid {
+			continue
+		}
+		if panelID != 0 && annotation.PanelID != panelID {
+			continue
+		}
+		if from != 0 && annotation.TimeEnd < from {
+			continue
+		}
+		if to != 0 && annotation.Time > to {
+			continue
+		}
+		if !matchesTags(annotation) {
+			continue
+		}
+
+		annotations = append(annotations, *annotation)
+	}
+
+	sort.SliceStable(annotations, func(i, j int) bool {
+		return annotations[i].Time > annotations[j].Time
+	})
+
+	if len(annotations) > limit {
+		annotations = annotations[:limit]
+	}
+
+	writeJSON(w, http.StatusOK, annotations)
+}
+
+func (server *Server) postAnnotation(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	annotation := &Annotation{}
+	if !server.decodeAnnotation(w, r, annotation) {
+		return
+	}
+
+	annotation.ID = server.generateID()
+	server.annotations = append(server.annotations, annotation)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":      annotation.ID,
+		"message": "Annotation added",
+	})
+}
+
+func (server *Server) putAnnotation(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	existing := server.annotationByID(params["id"])
+	if existing == nil {
+		writeError(w, http.StatusNotFound, "Annotation not found")
+		return
+	}
+
+	annotation := Annotation{}
+	if !server.decodeAnnotation(w, r, &annotation) {
+		return
+	}
+
+	annotation.ID = existing.ID
+	*existing = annotation
+
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Annotation updated"})
+}
+
+func (server *Server) deleteAnnotation(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	existing := server.annotationByID(params["id"])
+	if existing == nil {
+		writeError(w, http.StatusNotFound, "Annotation not found")
+		return
+	}
+
+	annotations := server.annotations[:0]
+	for _, annotation := range server.annotations {
+		if annotation != existing {
+			annotations = append(annotations, annotation)
+		}
+	}
+	server.annotations = annotations
+
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Annotation deleted"})
+}
@@ -0,0 +1,103 @@
+package grabanatest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// APIKey is an API key stored by the fake server.
+type APIKey struct {
+	ID            uint
+	Name          string
+	Role          string
+	SecondsToLive int
+	Key           string
+}
+
+// APIKeys returns a copy of the API keys currently stored.
+func (server *Server) APIKeys() []APIKey {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	keys := make([]APIKey, 0, len(server.apiKeys))
+	for _, key := range server.apiKeys {
+		keys = append(keys, *key)
+	}
+
+	return keys
+}
+
+func (server *Server) registerAPIKeyRoutes() {
+	server.handle(http.MethodGet, "/api/auth/keys", server.listAPIKeys)
+	server.handle(http.MethodPost, "/api/auth/keys", server.postAPIKey)
+	server.handle(http.MethodDelete, "/api/auth/keys/{id}", server.deleteAPIKey)
+}
+
+func (server *Server) listAPIKeys(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	keys := make([]map[string]interface{}, 0, len(server.apiKeys))
+	for _, key := range server.apiKeys {
+		keys = append(keys, map[string]interface{}{
+			"id":   key.ID,
+			"name": key.Name,
+			"role": key.Role,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, keys)
+}
+
+func (server *Server) postAPIKey(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	request := struct {
+		Name          string `json:"name"`
+		Role          string `json:"role"`
+		SecondsToLive int    `json:"secondsToLive"`
+	}{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	for _, key := range server.apiKeys {
+		if key.Name == request.Name {
+			writeError(w, http.StatusConflict, "API Key Organization ID And Name Must Be Unique")
+			return
+		}
+	}
+
+	key := &APIKey{
+		ID:            uint(server.generateID()),
+		Name:          request.Name,
+		Role:          request.Role,
+		SecondsToLive: request.SecondsToLive,
+	}
+	key.Key = fmt.Sprintf("fake-key-%d", key.ID)
+
+	server.apiKeys = append(server.apiKeys, key)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":   key.ID,
+		"name": key.Name,
+		"key":  key.Key,
+	})
+}
+
+func (server *Server) deleteAPIKey(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	id, err := strconv.Atoi(params["id"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "id is invalid")
+		return
+	}
+
+	for i, key := range server.apiKeys {
+		if key.ID != uint(id) {
+			continue
+		}
+
+		server.apiKeys = append(server.apiKeys[:i], server.apiKeys[i+1:]...)
+		writeJSON(w, http.StatusOK, map[string]string{"message": "API key deleted"})
+
+		return
+	}
+
+	writeError(w, http.StatusNotFound, "API key not found")
+}
@@ -0,0 +1,419 @@
+package grabanatest
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/K-Phoen/sdk"
+)
+
+// Dashboard is a dashboard stored by the fake server.
+type Dashboard struct {
+	ID        int
+	UID       string
+	Title     string
+	Tags      []string
+	FolderUID string
+	Version   int
+	// Message is the message attached to the last saved version.
+	Message string
+	// Model is the JSON model of the dashboard, as last saved.
+	Model json.RawMessage
+
+	versions []dashboardVersion
+}
+
+// Board decodes the JSON model of the dashboard.
+func (dashboard Dashboard) Board() (*sdk.Board, error) {
+	board := &sdk.Board{}
+	if err := json.Unmarshal(dashboard.Model, board); err != nil {
+		return nil, err
+	}
+
+	return board, nil
+}
+
+// Dashboards returns a copy of the dashboards currently stored.
+func (server *Server) Dashboards() []Dashboard {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	dashboards := make([]Dashboard, 0, len(server.dashboards))
+	for _, dashboard := range server.dashboards {
+		dashboards = append(dashboards, *dashboard)
+	}
+
+	return dashboards
+}
+
+// DashboardByTitle returns the stored dashboard with the given title, if any.
+func (server *Server) DashboardByTitle(title string) (Dashboard, bool) {
+	for _, dashboard := range server.Dashboards() {
+		if dashboard.Title == title {
+			return dashboard, true
+		}
+	}
+
+	return Dashboard{}, false
+}
+
+func (server *Server) registerDashboardRoutes() {
+	server.handle(http.MethodGet, "/api/search", server.search)
+	server.handle(http.MethodPost, "/api/dashboards/db", server.postDashboard)
+	server.handle(http.MethodGet, "/api/dashboards/uid/{uid}", server.getDashboard)
+	server.handle(http.MethodDelete, "/api/dashboards/uid/{uid}", server.deleteDashboard)
+}
+
+func (server *Server) dashboardByUID(uid string) *Dashboard {
+	for _, dashboard := range server.dashboards {
+		if dashboard.UID == uid {
+			return dashboard
+		}
+	}
+
+	return nil
+}
+
+func (server *Server) dashboardByID(id int) *Dashboard {
+	for _, dashboard := range server.dashboards {
+		if dashboard.ID == id {
+			return dashboard
+		}
+	}
+
+	return nil
+}
+
+func (server *Server) dashboardByTitle(folderUID string, title string) *Dashboard {
+	for _, dashboard := range server.dashboards {
+		if dashboard.FolderUID == folderUID && strings.EqualFold(dashboard.Title, title) {
+			return dashboard
+		}
+	}
+
+	return nil
+}
+
+func (server *Server) folderTitle(uid string) string {
+	if folder := server.folderByUID(uid); folder != nil {
+		return folder.Title
+	}
+
+	return "General"
+}
+
+// IgnoreSearchFolderFilter makes searches ignore the folderUIDs filter, like
+// older Grafana versions do.
+func (server *Server) IgnoreSearchFolderFilter() {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	server.ignoreFolderFilter = true
+}
+
+func (server *Server) search(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	query := r.URL.Query()
+
+	folderUIDs := query["folderUIDs"]
+	dashboardUIDs := query["dashboardUIDs"]
+	if server.ignoreFolderFilter {
+		folderUIDs = nil
+	}
+
+	inFolders := func(uid string) bool {
+		return len(folderUIDs) == 0 || containsString(folderUIDs, uid)
+	}
+
+	matchesDashboard := func(dashboard *Dashboard) bool {
+		for _, tag := range query["tag"] {
+			if !containsString(dashboard.Tags, tag) {
+				return false
+			}
+		}
+
+		return len(dashboardUIDs) == 0 || containsString(dashboardUIDs, dashboard.UID)
+	}
+
+	searchType := query.Get("type")
+	titleQuery := strings.ToLower(query.Get("query"))
+	hits := []map[string]interface{}{}
+
+	// stars are not supported: nothing is ever starred
+	if query.Get("starred") == "true" {
+		writeJSON(w, http.StatusOK, hits)
+		return
+	}
+
+	if searchType == "" || searchType == "dash-folder" {
+		for _, folder := range server.folders {
+			if !strings.Contains(strings.ToLower(folder.Title), titleQuery) || len(folderUIDs) != 0 || len(dashboardUIDs) != 0 || len(query["tag"]) != 0 {
+				continue
+			}
+
+			hits = append(hits, map[string]interface{}{
+				"id":    folder.ID,
+				"uid":   folder.UID,
+				"title": folder.Title,
+				"type":  "dash-folder",
+				"url":   "/dashboards/f/" + folder.UID,
+			})
+		}
+	}
+
+	if searchType == "" || searchType == "dash-db" {
+		for _, dashboard := range server.dashboards {
+			if !strings.Contains(strings.ToLower(dashboard.Title), titleQuery) || !inFolders(dashboard.FolderUID) || !matchesDashboard(dashboard) {
+				continue
+			}
+
+			hit := map[string]interface{}{
+				"id":          dashboard.ID,
+				"uid":         dashboard.UID,
+				"title":       dashboard.Title,
+				"type":        "dash-db",
+				"url":         "/d/" + dashboard.UID,
+				"tags":        dashboard.Tags,
+				"folderUid":   dashboard.FolderUID,
+				"folderTitle": server.folderTitle(dashboard.FolderUID),
+			}
+			if folder := server.folderByUID(dashboard.FolderUID); folder != nil {
+				hit["folderId"] = folder.ID
+			}
+
+			hits = append(hits, hit)
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return strings.ToLower(hits[i]["title"].(string)) < strings.ToLower(hits[j]["title"].(string))
+	})
+
+	writeJSON(w, http.StatusOK, paginate(hits, query.Get("limit"), query.Get("page")))
+}
+
+func paginate(hits []map[string]interface{}, limitParam string, pageParam string) []map[string]interface{} {
+	limit, err := strconv.Atoi(limitParam)
+	if err != nil || limit <= 0 {
+		limit = 1000
+	}
+	if limit > 5000 {
+		limit = 5000
+	}
+	page, err := strconv.Atoi(pageParam)
+	if err != nil || page <= 0 {
+		page = 1
+	}
+
+	start := (page - 1) * limit
+	if start >= len(hits) {
+		return []map[string]interface{}{}
+	}
+
+	end := start + limit
+	if end > len(hits) {
+		end = len(hits)
+	}
+
+	return hits[start:end]
+}
+
+func containsString(haystack []string, needle string) bool {
+	for _, candidate := range haystack {
+		if candidate == needle {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (server *Server) postDashboard(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	request := struct {
+		Dashboard map[string]interface{} `json:"dashboard"`
+		FolderUID string                 `json:"folderUid"`
+		Overwrite bool                   `json:"overwrite"`
+		Message   string                 `json:"message"`
+	}{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	if request.Dashboard == nil {
+		writeError(w, http.StatusBadRequest, "dashboard is required")
+		return
+	}
+
+	title, _ := request.Dashboard["title"].(string)
+	if strings.TrimSpace(title) == "" {
+		writeError(w, http.StatusBadRequest, "dashboard title cannot be empty")
+		return
+	}
+
+	if request.FolderUID != "" && server.folderByUID(request.FolderUID) == nil {
+		writeError(w, http.StatusBadRequest, "folder not found")
+		return
+	}
+
+	uid, _ := request.Dashboard["uid"].(string)
+	id := intValue(request.Dashboard["id"])
+
+	var existing *Dashboard
+	switch {
+	case uid != "":
+		existing = server.dashboardByUID(uid)
+	case id != 0:
+		existing = server.dashboardByID(id)
+		if existing == nil {
+			writeError(w, http.StatusNotFound, "Dashboard not found")
+			return
+		}
+	}
+
+	if sameTitle := server.dashboardByTitle(request.FolderUID, title); sameTitle != nil && sameTitle != existing {
+		if !request.Overwrite {
+			writeStatusError(w, http.StatusPreconditionFailed, "name-exists", "A dashboard with the same name in the folder already exists")
+			return
+		}
+
+		if existing == nil && uid == "" {
+			existing = sameTitle
+		} else {
+			server.removeDashboard(sameTitle.UID)
+		}
+	}
+
+	if existing != nil && !request.Overwrite && intValue(request.Dashboard["version"]) != existing.Version {
+		writeStatusError(w, http.StatusPreconditionFailed, "version-mismatch", "The dashboard has been changed by someone else")
+		return
+	}
+
+	if existing == nil {
+		existing = &Dashboard{ID: server.generateID(), UID: uid}
+		if existing.UID == "" {
+			existing.UID = server.generateUID("dashboard")
+		}
+
+		server.dashboards = append(server.dashboards, existing)
+	}
+
+	existing.FolderUID = request.FolderUID
+	if err := server.saveDashboardVersion(existing, request.Dashboard, request.Message, 0); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":      existing.ID,
+		"uid":     existing.UID,
+		"url":     "/d/" + existing.UID,
+		"status":  "success",
+		"version": existing.Version,
+	})
+}
+
+// saveDashboardVersion stores the given model as the new version of the
+// dashboard, and records it in its history.
+func (server *Server) saveDashboardVersion(dashboard *Dashboard, model map[string]interface{}, message string, restoredFrom int) error {
+	dashboard.Title, _ = model["title"].(string)
+	dashboard.Tags = stringSlice(model["tags"])
+	dashboard.Message = message
+	dashboard.Version++
+
+	model["id"] = dashboard.ID
+	model["uid"] = dashboard.UID
+	model["version"] = dashboard.Version
+
+	buf, err := json.Marshal(model)
+	if err != nil {
+		return err
+	}
+	dashboard.Model = buf
+
+	dashboard.versions = append(dashboard.versions, dashboardVersion{
+		ID:            server.generateID(),
+		DashboardID:   dashboard.ID,
+		ParentVersion: dashboard.Version - 1,
+		RestoredFrom:  restoredFrom,
+		Version:       dashboard.Version,
+		Created:       time.Now(),
+		CreatedBy:     server.currentLogin,
+		Message:       message,
+		Data:          buf,
+	})
+
+	return nil
+}
+
+func (server *Server) getDashboard(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	dashboard := server.dashboardByUID(params["uid"])
+	if dashboard == nil {
+		writeError(w, http.StatusNotFound, "Dashboard not found")
+		return
+	}
+
+	meta := map[string]interface{}{
+		"url":         "/d/" + dashboard.UID,
+		"version":     dashboard.Version,
+		"folderUid":   dashboard.FolderUID,
+		"folderTitle": server.folderTitle(dashboard.FolderUID),
+	}
+	if folder := server.folderByUID(dashboard.FolderUID); folder != nil {
+		meta["folderId"] = folder.ID
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"dashboard": dashboard.Model,
+		"meta":      meta,
+	})
+}
+
+func (server *Server) deleteDashboard(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	dashboard := server.dashboardByUID(params["uid"])
+	if dashboard == nil {
+		writeError(w, http.StatusNotFound, "Dashboard not found")
+		return
+	}
+
+	server.removeDashboard(dashboard.UID)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":      dashboard.ID,
+		"title":   dashboard.Title,
+		"message": "Dashboard " + dashboard.Title + " deleted",
+	})
+}
+
+func (server *Server) removeDashboard(uid string) {
+	delete(server.dashboardPermissions, uid)
+
+	dashboards := server.dashboards[:0]
+	for _, dashboard := range server.dashboards {
+		if dashboard.UID != uid {
+			dashboards = append(dashboards, dashboard)
+		}
+	}
+	server.dashboards = dashboards
+}
+
+func intValue(value interface{}) int {
+	number, _ := value.(float64)
+
+	return int(number)
+}
+
+func stringSlice(value interface{}) []string {
+	items, _ := value.([]interface{})
+
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		if str, ok := item.(string); ok {
+			values = append(values, str)
+		}
+	}
+
+	return values
+}
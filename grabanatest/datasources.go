@@ -0,0 +1,284 @@
+package grabanatest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// Datasource is a datasource stored by the fake server.
+type Datasource struct {
+	ID        int
+	UID       string
+	Name      string
+	Type      string
+	IsDefault bool
+	// Healthy and HealthMessage are reported by the health check endpoint.
+	Healthy       bool
+	HealthMessage string
+	// NoHealthCheck mimics plugins that do not implement health checks.
+	NoHealthCheck bool
+	// Model is the JSON model of the datasource, as last saved.
+	Model json.RawMessage
+}
+
+// AddDatasource creates a datasource, bypassing the HTTP API.
+func (server *Server) AddDatasource(name string, datasourceType string, isDefault bool) Datasource {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	datasource := &Datasource{
+		ID:        server.generateID(),
+		UID:       server.generateUID("datasource"),
+		Name:      name,
+		Type:      datasourceType,
+		IsDefault: isDefault,
+		Healthy:   true,
+	}
+	datasource.Model, _ = json.Marshal(datasource.payload())
+
+	server.datasources = append(server.datasources, datasource)
+
+	return *datasource
+}
+
+// SetDatasourceHealth sets the result of the health check of the
+// datasource with the given name. Datasources are healthy by default.
+func (server *Server) SetDatasourceHealth(name string, healthy bool, message string) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	if datasource := server.datasourceByName(name); datasource != nil {
+		datasource.Healthy = healthy
+		datasource.HealthMessage = message
+	}
+}
+
+// DisableDatasourceHealthCheck makes the datasource with the given name
+// behave like plugins that do not implement health checks.
+func (server *Server) DisableDatasourceHealthCheck(name string) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	if datasource := server.datasourceByName(name); datasource != nil {
+		datasource.NoHealthCheck = true
+	}
+}
+
+// Datasources returns a copy of the datasources currently stored.
+func (server *Server) Datasources() []Datasource {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	datasources := make([]Datasource, 0, len(server.datasources))
+	for _, datasource := range server.datasources {
+		datasources = append(datasources, *datasource)
+	}
+
+	return datasources
+}
+
+func (server *Server) registerDatasourceRoutes() {
+	server.handle(http.MethodGet, "/api/datasources", server.listDatasources)
+	server.handle(http.MethodPost, "/api/datasources", server.postDatasource)
+	server.handle(http.MethodPut, "/api/datasources/{id}", server.putDatasource)
+	server.handle(http.MethodDelete, "/api/datasources/{id}", server.deleteDatasource)
+	server.handle(http.MethodGet, "/api/datasources/name/{name}", server.getDatasourceByName)
+	server.handle(http.MethodGet, "/api/datasources/uid/{uid}", server.getDatasourceByUID)
+	server.handle(http.MethodGet, "/api/datasources/uid/{uid}/health", server.checkDatasourceHealth)
+	server.handle(http.MethodGet, "/api/datasources/id/{name}", server.getDatasourceIDByName)
+}
+
+func (datasource *Datasource) payload() map[string]interface{} {
+	payload := map[string]interface{}{}
+	_ = json.Unmarshal(datasource.Model, &payload)
+
+	payload["id"] = datasource.ID
+	payload["uid"] = datasource.UID
+	payload["name"] = datasource.Name
+	payload["type"] = datasource.Type
+	payload["isDefault"] = datasource.IsDefault
+
+	return payload
+}
+
+func (server *Server) datasourceBy(predicate func(datasource *Datasource) bool) *Datasource {
+	for _, datasource := range server.datasources {
+		if predicate(datasource) {
+			return datasource
+		}
+	}
+
+	return nil
+}
+
+func (server *Server) datasourceByName(name string) *Datasource {
+	return server.datasourceBy(func(datasource *Datasource) bool { return datasource.Name == name })
+}
+
+func (server *Server) datasourceFromPath(w http.ResponseWriter, params map[string]string) *Datasource {
+	id, err := strconv.Atoi(params["id"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "id is invalid")
+		return nil
+	}
+
+	datasource := server.datasourceBy(func(datasource *Datasource) bool { return datasource.ID == id })
+	if datasource == nil {
+		writeError(w, http.StatusNotFound, "Data source not found")
+		return nil
+	}
+
+	return datasource
+}
+
+func (server *Server) saveDatasource(datasource *Datasource, payload map[string]interface{}) {
+	datasource.Name, _ = payload["name"].(string)
+	datasource.Type, _ = payload["type"].(string)
+	datasource.IsDefault, _ = payload["isDefault"].(bool)
+
+	if uid, ok := payload["uid"].(string); ok && uid != "" {
+		datasource.UID = uid
+	}
+	if datasource.UID == "" {
+		datasource.UID = server.generateUID("datasource")
+	}
+
+	if datasource.IsDefault {
+		for _, other := range server.datasources {
+			if other != datasource {
+				other.IsDefault = false
+			}
+		}
+	}
+
+	// the stored model reflects generated fields, such as the UID
+	datasource.Model, _ = json.Marshal(payload)
+	datasource.Model, _ = json.Marshal(datasource.payload())
+}
+
+func (server *Server) listDatasources(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	datasources := make([]map[string]interface{}, 0, len(server.datasources))
+	for _, datasource := range server.datasources {
+		datasources = append(datasources, datasource.payload())
+	}
+
+	writeJSON(w, http.StatusOK, datasources)
+}
+
+func (server *Server) postDatasource(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	payload := map[string]interface{}{}
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+
+	name, _ := payload["name"].(string)
+	if server.datasourceByName(name) != nil {
+		writeError(w, http.StatusConflict, "data source with the same name already exists")
+		return
+	}
+
+	datasource := &Datasource{ID: server.generateID(), Healthy: true}
+	server.saveDatasource(datasource, payload)
+	server.datasources = append(server.datasources, datasource)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":         datasource.ID,
+		"name":       datasource.Name,
+		"message":    "Datasource added",
+		"datasource": datasource.payload(),
+	})
+}
+
+func (server *Server) putDatasource(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	datasource := server.datasourceFromPath(w, params)
+	if datasource == nil {
+		return
+	}
+
+	payload := map[string]interface{}{}
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+
+	server.saveDatasource(datasource, payload)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":         datasource.ID,
+		"name":       datasource.Name,
+		"message":    "Datasource updated",
+		"datasource": datasource.payload(),
+	})
+}
+
+func (server *Server) deleteDatasource(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	datasource := server.datasourceFromPath(w, params)
+	if datasource == nil {
+		return
+	}
+
+	datasources := server.datasources[:0]
+	for _, candidate := range server.datasources {
+		if candidate != datasource {
+			datasources = append(datasources, candidate)
+		}
+	}
+	server.datasources = datasources
+
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Data source deleted"})
+}
+
+func (server *Server) getDatasourceByName(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	datasource := server.datasourceByName(params["name"])
+	if datasource == nil {
+		writeError(w, http.StatusNotFound, "Data source not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, datasource.payload())
+}
+
+func (server *Server) getDatasourceByUID(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	datasource := server.datasourceBy(func(datasource *Datasource) bool { return datasource.UID == params["uid"] })
+	if datasource == nil {
+		writeError(w, http.StatusNotFound, "Data source not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, datasource.payload())
+}
+
+func (server *Server) checkDatasourceHealth(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	datasource := server.datasourceBy(func(datasource *Datasource) bool { return datasource.UID == params["uid"] })
+	if datasource == nil {
+		writeError(w, http.StatusNotFound, "Data source not found")
+		return
+	}
+
+	if datasource.NoHealthCheck {
+		writeError(w, http.StatusNotFound, "Health check not implemented")
+		return
+	}
+
+	if !datasource.Healthy {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"status": "ERROR", "message": datasource.HealthMessage})
+		return
+	}
+
+	message := datasource.HealthMessage
+	if message == "" {
+		message = "Data source is working"
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "OK", "message": message})
+}
+
+func (server *Server) getDatasourceIDByName(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	datasource := server.datasourceByName(params["name"])
+	if datasource == nil {
+		writeError(w, http.StatusNotFound, "Data source not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]int{"id": datasource.ID})
+}
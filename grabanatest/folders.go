@@ -0,0 +1,268 @@
+package grabanatest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/K-Phoen/grabana"
+)
+
+// Folder is a folder stored by the fake server.
+type Folder struct {
+	ID        uint   `json:"id"`
+	UID       string `json:"uid"`
+	Title     string `json:"title"`
+	ParentUID string `json:"parentUid,omitempty"`
+}
+
+// AddFolder creates a folder, bypassing the HTTP API.
+func (server *Server) AddFolder(title string) grabana.Folder {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	folder := server.createFolder(title, "", "")
+
+	return grabana.Folder{ID: folder.ID, UID: folder.UID, Title: folder.Title}
+}
+
+// AddChildFolder creates a folder in the given parent folder, bypassing the
+// HTTP API.
+func (server *Server) AddChildFolder(parentUID string, title string) grabana.Folder {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	folder := server.createFolder(title, "", parentUID)
+
+	return grabana.Folder{ID: folder.ID, UID: folder.UID, Title: folder.Title, ParentUID: folder.ParentUID}
+}
+
+// Folders returns a copy of the folders currently stored.
+func (server *Server) Folders() []Folder {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	folders := make([]Folder, 0, len(server.folders))
+	for _, folder := range server.folders {
+		folders = append(folders, *folder)
+	}
+
+	return folders
+}
+
+func (server *Server) registerFolderRoutes() {
+	server.handle(http.MethodGet, "/api/folders", server.listFolders)
+	server.handle(http.MethodPost, "/api/folders", server.postFolder)
+	server.handle(http.MethodGet, "/api/folders/{uid}", server.getFolder)
+	server.handle(http.MethodDelete, "/api/folders/{uid}", server.deleteFolder)
+	server.handle(http.MethodPost, "/api/folders/{uid}/move", server.moveFolder)
+}
+
+func (server *Server) createFolder(title string, uid string, parentUID string) *Folder {
+	if uid == "" {
+		uid = server.generateUID("folder")
+	}
+
+	folder := &Folder{ID: uint(server.generateID()), UID: uid, Title: title, ParentUID: parentUID}
+	server.folders = append(server.folders, folder)
+
+	return folder
+}
+
+func (server *Server) folderByUID(uid string) *Folder {
+	for _, folder := range server.folders {
+		if folder.UID == uid {
+			return folder
+		}
+	}
+
+	return nil
+}
+
+func (server *Server) folderByTitle(parentUID string, title string) *Folder {
+	for _, folder := range server.folders {
+		if folder.ParentUID == parentUID && strings.EqualFold(folder.Title, title) {
+			return folder
+		}
+	}
+
+	return nil
+}
+
+// isDescendant tells whether the folder identified by uid is the given
+// ancestor or one of its descendants.
+func (server *Server) isDescendant(uid string, ancestorUID string) bool {
+	for uid != "" {
+		if uid == ancestorUID {
+			return true
+		}
+
+		folder := server.folderByUID(uid)
+		if folder == nil {
+			return false
+		}
+
+		uid = folder.ParentUID
+	}
+
+	return false
+}
+
+func (server *Server) listFolders(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	parentUID := r.URL.Query().Get("parentUid")
+
+	folders := make([]Folder, 0, len(server.folders))
+	for _, folder := range server.folders {
+		if folder.ParentUID == parentUID {
+			folders = append(folders, *folder)
+		}
+	}
+
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 1000
+	}
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+
+	start := (page - 1) * limit
+	if start > len(folders) {
+		start = len(folders)
+	}
+	end := start + limit
+	if end > len(folders) {
+		end = len(folders)
+	}
+
+	writeJSON(w, http.StatusOK, folders[start:end])
+}
+
+func (server *Server) postFolder(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	request := struct {
+		UID       string `json:"uid"`
+		Title     string `json:"title"`
+		ParentUID string `json:"parentUid"`
+	}{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	if strings.TrimSpace(request.Title) == "" {
+		writeError(w, http.StatusBadRequest, "folder title cannot be empty")
+		return
+	}
+
+	if request.ParentUID != "" && server.folderByUID(request.ParentUID) == nil {
+		writeError(w, http.StatusNotFound, "parent folder not found")
+		return
+	}
+
+	if server.folderByTitle(request.ParentUID, request.Title) != nil {
+		writeError(w, http.StatusConflict, "a folder with the same name already exists")
+		return
+	}
+	if request.UID != "" && server.folderByUID(request.UID) != nil {
+		writeError(w, http.StatusConflict, "a folder with the same uid already exists")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, server.createFolder(request.Title, request.UID, request.ParentUID))
+}
+
+func (server *Server) getFolder(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	folder := server.folderByUID(params["uid"])
+	if folder == nil {
+		writeError(w, http.StatusNotFound, "folder not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, folder)
+}
+
+func (server *Server) moveFolder(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	folder := server.folderByUID(params["uid"])
+	if folder == nil {
+		writeError(w, http.StatusNotFound, "folder not found")
+		return
+	}
+
+	request := struct {
+		ParentUID string `json:"parentUid"`
+	}{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	if request.ParentUID != "" && server.folderByUID(request.ParentUID) == nil {
+		writeError(w, http.StatusNotFound, "parent folder not found")
+		return
+	}
+	if server.isDescendant(request.ParentUID, folder.UID) {
+		writeError(w, http.StatusBadRequest, "folder cannot be moved under itself")
+		return
+	}
+	if sameTitle := server.folderByTitle(request.ParentUID, folder.Title); sameTitle != nil && sameTitle != folder {
+		writeError(w, http.StatusConflict, "a folder with the same name already exists")
+		return
+	}
+
+	folder.ParentUID = request.ParentUID
+
+	writeJSON(w, http.StatusOK, folder)
+}
+
+func (server *Server) deleteFolder(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	folder := server.folderByUID(params["uid"])
+	if folder == nil {
+		writeError(w, http.StatusNotFound, "folder not found")
+		return
+	}
+
+	// subfolders are deleted along with their parent
+	var deleted []string
+	for _, candidate := range server.folders {
+		if server.isDescendant(candidate.UID, folder.UID) {
+			deleted = append(deleted, candidate.UID)
+		}
+	}
+
+	var rules []string
+	for _, uid := range deleted {
+		rules = append(rules, server.alertRulesInFolder(uid)...)
+	}
+
+	forceDeleteRules := r.URL.Query().Get("forceDeleteRules") == "true"
+	if len(rules) != 0 && !forceDeleteRules {
+		writeError(w, http.StatusBadRequest, "folder cannot be deleted: folder contains alert rules")
+		return
+	}
+
+	for _, uid := range rules {
+		server.removeAlertRule(uid)
+	}
+
+	for _, uid := range deleted {
+		for _, dashboard := range append([]*Dashboard{}, server.dashboards...) {
+			if dashboard.FolderUID == uid {
+				server.removeDashboard(dashboard.UID)
+			}
+		}
+		delete(server.folderPermissions, uid)
+	}
+
+	folders := server.folders[:0]
+	for _, candidate := range server.folders {
+		if !containsString(deleted, candidate.UID) {
+			folders = append(folders, candidate)
+		}
+	}
+	server.folders = folders
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":      folder.ID,
+		"title":   folder.Title,
+		"message": "Folder " + folder.Title + " deleted",
+	})
+}
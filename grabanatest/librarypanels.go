@@ -0,0 +1,218 @@
+package grabanatest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// LibraryPanel is a library panel stored by the fake server.
+type LibraryPanel struct {
+	ID        int             `json:"id"`
+	UID       string          `json:"uid"`
+	Name      string          `json:"name"`
+	Kind      int             `json:"kind"`
+	FolderUID string          `json:"folderUid"`
+	Type      string          `json:"type"`
+	Version   int             `json:"version"`
+	Model     json.RawMessage `json:"model"`
+}
+
+// LibraryPanels returns a copy of the library panels currently stored.
+func (server *Server) LibraryPanels() []LibraryPanel {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	panels := make([]LibraryPanel, 0, len(server.libraryPanels))
+	for _, panel := range server.libraryPanels {
+		panels = append(panels, *panel)
+	}
+
+	return panels
+}
+
+func (server *Server) registerLibraryPanelRoutes() {
+	server.handle(http.MethodPost, "/api/library-elements", server.postLibraryPanel)
+	server.handle(http.MethodGet, "/api/library-elements/{uid}", server.getLibraryPanel)
+	server.handle(http.MethodPatch, "/api/library-elements/{uid}", server.patchLibraryPanel)
+	server.handle(http.MethodDelete, "/api/library-elements/{uid}", server.deleteLibraryPanel)
+}
+
+type libraryPanelRequest struct {
+	UID       string                 `json:"uid"`
+	FolderUID string                 `json:"folderUid"`
+	Name      string                 `json:"name"`
+	Kind      int                    `json:"kind"`
+	Model     map[string]interface{} `json:"model"`
+	Version   int                    `json:"version"`
+}
+
+func (server *Server) libraryPanelByUID(uid string) *LibraryPanel {
+	for _, panel := range server.libraryPanels {
+		if panel.UID == uid {
+			return panel
+		}
+	}
+
+	return nil
+}
+
+// libraryPanelConnected tells whether a dashboard references the given
+// library panel.
+func (server *Server) libraryPanelConnected(uid string) bool {
+	for _, dashboard := range server.dashboards {
+		var model interface{}
+		if err := json.Unmarshal(dashboard.Model, &model); err != nil {
+			continue
+		}
+
+		if referencesLibraryPanel(model, uid) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func referencesLibraryPanel(node interface{}, uid string) bool {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		if ref, ok := value["libraryPanel"].(map[string]interface{}); ok && ref["uid"] == uid {
+			return true
+		}
+
+		for _, child := range value {
+			if referencesLibraryPanel(child, uid) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, child := range value {
+			if referencesLibraryPanel(child, uid) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (server *Server) saveLibraryPanel(w http.ResponseWriter, panel *LibraryPanel, request libraryPanelRequest) bool {
+	if strings.TrimSpace(request.Name) == "" {
+		writeError(w, http.StatusBadRequest, "library element name cannot be empty")
+		return false
+	}
+	if request.FolderUID != "" && server.folderByUID(request.FolderUID) == nil {
+		writeError(w, http.StatusBadRequest, "folder not found")
+		return false
+	}
+
+	for _, candidate := range server.libraryPanels {
+		if candidate != panel && candidate.FolderUID == request.FolderUID && candidate.Name == request.Name {
+			writeError(w, http.StatusBadRequest, "library element with that name already exists")
+			return false
+		}
+	}
+
+	if request.Model == nil {
+		request.Model = map[string]interface{}{}
+	}
+	request.Model["libraryPanel"] = map[string]interface{}{"uid": panel.UID, "name": request.Name}
+
+	model, err := json.Marshal(request.Model)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return false
+	}
+
+	panel.Name = request.Name
+	panel.Kind = request.Kind
+	panel.FolderUID = request.FolderUID
+	panel.Type, _ = request.Model["type"].(string)
+	panel.Model = model
+	panel.Version++
+
+	return true
+}
+
+func (server *Server) postLibraryPanel(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	request := libraryPanelRequest{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	if request.UID != "" && server.libraryPanelByUID(request.UID) != nil {
+		writeError(w, http.StatusBadRequest, "library element with that uid already exists")
+		return
+	}
+
+	panel := &LibraryPanel{ID: server.generateID(), UID: request.UID}
+	if panel.UID == "" {
+		panel.UID = server.generateUID("library-panel")
+	}
+
+	if !server.saveLibraryPanel(w, panel, request) {
+		return
+	}
+
+	server.libraryPanels = append(server.libraryPanels, panel)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"result": panel})
+}
+
+func (server *Server) getLibraryPanel(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	panel := server.libraryPanelByUID(params["uid"])
+	if panel == nil {
+		writeError(w, http.StatusNotFound, "library element could not be found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"result": panel})
+}
+
+func (server *Server) patchLibraryPanel(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	panel := server.libraryPanelByUID(params["uid"])
+	if panel == nil {
+		writeError(w, http.StatusNotFound, "library element could not be found")
+		return
+	}
+
+	request := libraryPanelRequest{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	if request.Version != panel.Version {
+		writeError(w, http.StatusPreconditionFailed, "the library element has been changed by someone else")
+		return
+	}
+
+	if !server.saveLibraryPanel(w, panel, request) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"result": panel})
+}
+
+func (server *Server) deleteLibraryPanel(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	panel := server.libraryPanelByUID(params["uid"])
+	if panel == nil {
+		writeError(w, http.StatusNotFound, "library element could not be found")
+		return
+	}
+
+	if server.libraryPanelConnected(panel.UID) {
+		writeError(w, http.StatusForbidden, "the library element has connections")
+		return
+	}
+
+	panels := server.libraryPanels[:0]
+	for _, candidate := range server.libraryPanels {
+		if candidate != panel {
+			panels = append(panels, candidate)
+		}
+	}
+	server.libraryPanels = panels
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"id": panel.ID, "message": "Library element deleted"})
+}
@@ -0,0 +1,100 @@
+package grabanatest
+
+import (
+	"net/http"
+
+	"github.com/K-Phoen/grabana"
+)
+
+// FolderPermissions returns the permissions set on the given folder.
+func (server *Server) FolderPermissions(folderUID string) []grabana.Permission {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	return append([]grabana.Permission{}, server.folderPermissions[folderUID]...)
+}
+
+// DashboardPermissions returns the permissions set on the given dashboard,
+// excluding the ones inherited from its folder.
+func (server *Server) DashboardPermissions(dashboardUID string) []grabana.Permission {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	return append([]grabana.Permission{}, server.dashboardPermissions[dashboardUID]...)
+}
+
+func (server *Server) registerPermissionRoutes() {
+	server.handle(http.MethodGet, "/api/folders/{uid}/permissions", server.getFolderPermissions)
+	server.handle(http.MethodPost, "/api/folders/{uid}/permissions", server.postFolderPermissions)
+	server.handle(http.MethodGet, "/api/dashboards/uid/{uid}/permissions", server.getDashboardPermissions)
+	server.handle(http.MethodPost, "/api/dashboards/uid/{uid}/permissions", server.postDashboardPermissions)
+}
+
+func decodePermissions(w http.ResponseWriter, r *http.Request) ([]grabana.Permission, bool) {
+	request := struct {
+		Items []grabana.Permission `json:"items"`
+	}{}
+	if !decodeBody(w, r, &request) {
+		return nil, false
+	}
+
+	return request.Items, true
+}
+
+func (server *Server) getFolderPermissions(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	if server.folderByUID(params["uid"]) == nil {
+		writeError(w, http.StatusNotFound, "folder not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, append([]grabana.Permission{}, server.folderPermissions[params["uid"]]...))
+}
+
+func (server *Server) postFolderPermissions(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if server.folderByUID(params["uid"]) == nil {
+		writeError(w, http.StatusNotFound, "folder not found")
+		return
+	}
+
+	permissions, ok := decodePermissions(w, r)
+	if !ok {
+		return
+	}
+
+	server.folderPermissions[params["uid"]] = permissions
+
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Folder permissions updated"})
+}
+
+func (server *Server) getDashboardPermissions(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	dashboard := server.dashboardByUID(params["uid"])
+	if dashboard == nil {
+		writeError(w, http.StatusNotFound, "Dashboard not found")
+		return
+	}
+
+	permissions := []grabana.Permission{}
+	for _, permission := range server.folderPermissions[dashboard.FolderUID] {
+		permission.Inherited = true
+		permissions = append(permissions, permission)
+	}
+	permissions = append(permissions, server.dashboardPermissions[dashboard.UID]...)
+
+	writeJSON(w, http.StatusOK, permissions)
+}
+
+func (server *Server) postDashboardPermissions(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if server.dashboardByUID(params["uid"]) == nil {
+		writeError(w, http.StatusNotFound, "Dashboard not found")
+		return
+	}
+
+	permissions, ok := decodePermissions(w, r)
+	if !ok {
+		return
+	}
+
+	server.dashboardPermissions[params["uid"]] = permissions
+
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Dashboard permissions updated"})
+}
@@ -0,0 +1,135 @@
+package grabanatest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/K-Phoen/grabana/playlist"
+)
+
+// Playlist is a playlist stored by the fake server.
+type Playlist struct {
+	ID       int             `json:"id"`
+	UID      string          `json:"uid"`
+	Name     string          `json:"name"`
+	Interval string          `json:"interval"`
+	Items    []playlist.Item `json:"items"`
+}
+
+// Playlists returns a copy of the playlists currently stored.
+func (server *Server) Playlists() []Playlist {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	playlists := make([]Playlist, 0, len(server.playlists))
+	for _, stored := range server.playlists {
+		playlists = append(playlists, *stored)
+	}
+
+	return playlists
+}
+
+func (server *Server) registerPlaylistRoutes() {
+	server.handle(http.MethodGet, "/api/playlists", server.listPlaylists)
+	server.handle(http.MethodPost, "/api/playlists", server.postPlaylist)
+	server.handle(http.MethodGet, "/api/playlists/{uid}", server.getPlaylist)
+	server.handle(http.MethodPut, "/api/playlists/{uid}", server.putPlaylist)
+	server.handle(http.MethodDelete, "/api/playlists/{uid}", server.deletePlaylist)
+}
+
+func (server *Server) playlistByUID(uid string) *Playlist {
+	for _, stored := range server.playlists {
+		if stored.UID == uid {
+			return stored
+		}
+	}
+
+	return nil
+}
+
+func (server *Server) listPlaylists(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	query := strings.ToLower(r.URL.Query().Get("query"))
+
+	playlists := []map[string]interface{}{}
+	for _, stored := range server.playlists {
+		if query != "" && !strings.Contains(strings.ToLower(stored.Name), query) {
+			continue
+		}
+
+		playlists = append(playlists, map[string]interface{}{
+			"id":       stored.ID,
+			"uid":      stored.UID,
+			"name":     stored.Name,
+			"interval": stored.Interval,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, playlists)
+}
+
+func (server *Server) postPlaylist(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	request := Playlist{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	if request.UID == "" {
+		request.UID = server.generateUID("playlist")
+	}
+	if server.playlistByUID(request.UID) != nil {
+		writeError(w, http.StatusConflict, "playlist with the same uid already exists")
+		return
+	}
+
+	request.ID = server.generateID()
+	server.playlists = append(server.playlists, &request)
+
+	writeJSON(w, http.StatusOK, request)
+}
+
+func (server *Server) getPlaylist(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	stored := server.playlistByUID(params["uid"])
+	if stored == nil {
+		writeError(w, http.StatusNotFound, "Playlist not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, stored)
+}
+
+func (server *Server) putPlaylist(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	stored := server.playlistByUID(params["uid"])
+	if stored == nil {
+		writeError(w, http.StatusNotFound, "Playlist not found")
+		return
+	}
+
+	request := Playlist{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	stored.Name = request.Name
+	stored.Interval = request.Interval
+	stored.Items = request.Items
+
+	writeJSON(w, http.StatusOK, stored)
+}
+
+func (server *Server) deletePlaylist(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	stored := server.playlistByUID(params["uid"])
+	if stored == nil {
+		writeError(w, http.StatusNotFound, "Playlist not found")
+		return
+	}
+
+	playlists := server.playlists[:0]
+	for _, candidate := range server.playlists {
+		if candidate != stored {
+			playlists = append(playlists, candidate)
+		}
+	}
+	server.playlists = playlists
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
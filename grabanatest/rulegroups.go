@@ -0,0 +1,147 @@
+package grabanatest
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+func (server *Server) registerRuleGroupRoutes() {
+	server.handle(http.MethodGet, "/api/v1/provisioning/folder/{folder}/rule-groups/{group}", server.getRuleGroup)
+	server.handle(http.MethodPut, "/api/v1/provisioning/folder/{folder}/rule-groups/{group}", server.putRuleGroup)
+	server.handle(http.MethodDelete, "/api/v1/provisioning/folder/{folder}/rule-groups/{group}", server.deleteRuleGroup)
+}
+
+func ruleGroupKey(folderUID string, group string) string {
+	return folderUID + "/" + group
+}
+
+func (server *Server) rulesInGroup(folderUID string, group string) []json.RawMessage {
+	var rules []json.RawMessage
+	for _, model := range server.alertRules {
+		rule := alertRuleFromModel(model)
+		if rule.FolderUID == folderUID && rule.RuleGroup == group {
+			rules = append(rules, model)
+		}
+	}
+
+	return rules
+}
+
+func (server *Server) removeRuleGroup(folderUID string, group string) {
+	rules := make([]json.RawMessage, 0, len(server.alertRules))
+	for _, model := range server.alertRules {
+		rule := alertRuleFromModel(model)
+		if rule.FolderUID != folderUID || rule.RuleGroup != group {
+			rules = append(rules, model)
+		}
+	}
+
+	server.alertRules = rules
+	delete(server.ruleGroupIntervals, ruleGroupKey(folderUID, group))
+}
+
+// ruleGroupInterval returns the evaluation interval of a group, and whether
+// it exists. Like in Grafana, groups only exist as long as they hold rules.
+// Groups implicitly created by rules sent one by one use the default
+// interval.
+func (server *Server) ruleGroupInterval(folderUID string, group string) (int64, bool) {
+	if len(server.rulesInGroup(folderUID, group)) == 0 {
+		return 0, false
+	}
+
+	if interval, ok := server.ruleGroupIntervals[ruleGroupKey(folderUID, group)]; ok {
+		return interval, true
+	}
+
+	return 60, true
+}
+
+func (server *Server) getRuleGroup(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	interval, ok := server.ruleGroupInterval(params["folder"], params["group"])
+	if !ok {
+		writeError(w, http.StatusNotFound, "rule group not found")
+		return
+	}
+
+	rules := []json.RawMessage{}
+	rules = append(rules, server.rulesInGroup(params["folder"], params["group"])...)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"title":     params["group"],
+		"folderUid": params["folder"],
+		"interval":  interval,
+		"rules":     rules,
+	})
+}
+
+func (server *Server) putRuleGroup(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	folderUID, group := params["folder"], params["group"]
+
+	payload := struct {
+		Interval int64                    `json:"interval"`
+		Rules    []map[string]interface{} `json:"rules"`
+	}{}
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+
+	if server.folderByUID(folderUID) == nil {
+		writeError(w, http.StatusBadRequest, "invalid rule group: folder does not exist")
+		return
+	}
+	if payload.Interval <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid rule group: interval must be positive")
+		return
+	}
+
+	// the whole group is validated before being stored, to mimic Grafana's
+	// atomic updates
+	rules := make([]json.RawMessage, 0, len(payload.Rules))
+	titles := map[string]bool{}
+	for _, rule := range payload.Rules {
+		title, _ := rule["title"].(string)
+		if title == "" || titles[title] {
+			writeError(w, http.StatusBadRequest, "invalid alert rule: titles are required and must be unique")
+			return
+		}
+		titles[title] = true
+
+		if uid, _ := rule["uid"].(string); uid == "" {
+			rule["uid"] = server.generateUID("alert")
+		}
+		rule["folderUID"] = folderUID
+		rule["ruleGroup"] = group
+
+		model, err := json.Marshal(rule)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		rules = append(rules, model)
+	}
+
+	server.removeRuleGroup(folderUID, group)
+	server.alertRules = append(server.alertRules, rules...)
+	if len(rules) != 0 {
+		server.ruleGroupIntervals[ruleGroupKey(folderUID, group)] = payload.Interval
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"title":     group,
+		"folderUid": folderUID,
+		"interval":  payload.Interval,
+		"rules":     rules,
+	})
+}
+
+func (server *Server) deleteRuleGroup(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	if _, ok := server.ruleGroupInterval(params["folder"], params["group"]); !ok {
+		writeError(w, http.StatusNotFound, "rule group not found")
+		return
+	}
+
+	server.removeRuleGroup(params["folder"], params["group"])
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,199 @@
+// Package grabanatest provides a stateful, in-memory fake of the Grafana HTTP
+// API endpoints used by grabana.Client.
+//
+// It is meant to be used in unit tests: flows such as UpsertDashboard or
+// DeleteFolder can be run against it, and the resulting state can then be
+// inspected.
+//
+//	server := grabanatest.NewServer()
+//	defer server.Close()
+//
+//	client := server.Client()
+//	_, err := client.UpsertDashboard(ctx, folder, builder)
+//
+//	dashboards := server.Dashboards()
+package grabanatest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/K-Phoen/grabana"
+)
+
+type handlerFunc func(w http.ResponseWriter, r *http.Request, params map[string]string)
+
+type route struct {
+	method   string
+	segments []string
+	handler  handlerFunc
+}
+
+// Server is an in-memory fake of the Grafana HTTP API.
+type Server struct {
+	*httptest.Server
+
+	lock         sync.Mutex
+	routes       []route
+	nextID       int
+	currentLogin string
+	// ignoreFolderFilter mimics Grafana versions ignoring the folderUIDs
+	// search filter.
+	ignoreFolderFilter bool
+
+	folders            []*Folder
+	dashboards         []*Dashboard
+	datasources        []*Datasource
+	alertRules         []json.RawMessage
+	ruleGroupIntervals map[string]int64
+	apiKeys            []*APIKey
+	annotations        []*Annotation
+	libraryPanels      []*LibraryPanel
+	users              []*User
+	teams              []*Team
+	snapshots          []*Snapshot
+	playlists          []*Playlist
+	alertManagerConfig json.RawMessage
+
+	contactPoints        []*ContactPoint
+	notificationPolicies json.RawMessage
+	messageTemplates     map[string]string
+	muteTimings          []*MuteTiming
+
+	folderPermissions    map[string][]grabana.Permission
+	dashboardPermissions map[string][]grabana.Permission
+}
+
+// NewServer starts a new fake Grafana server. It must be closed once the
+// test is done.
+func NewServer() *Server {
+	server := &Server{
+		currentLogin:         defaultLogin,
+		ruleGroupIntervals:   map[string]int64{},
+		folderPermissions:    map[string][]grabana.Permission{},
+		dashboardPermissions: map[string][]grabana.Permission{},
+	}
+
+	server.seedAlerting()
+
+	server.registerFolderRoutes()
+	server.registerDashboardRoutes()
+	server.registerDatasourceRoutes()
+	server.registerAlertRoutes()
+	server.registerRuleGroupRoutes()
+	server.registerAPIKeyRoutes()
+	server.registerPermissionRoutes()
+	server.registerVersionRoutes()
+	server.registerUserRoutes()
+	server.registerAnnotationRoutes()
+	server.registerLibraryPanelRoutes()
+	server.registerTeamRoutes()
+	server.registerSnapshotRoutes()
+	server.registerPlaylistRoutes()
+	server.registerAlertingRoutes()
+
+	server.Server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+
+	return server
+}
+
+// Client returns a grabana client configured to talk to this server.
+func (server *Server) Client(options ...grabana.Option) *grabana.Client {
+	return grabana.NewClient(server.Server.Client(), server.URL, options...)
+}
+
+func (server *Server) handle(method string, pattern string, handler handlerFunc) {
+	server.routes = append(server.routes, route{
+		method:   method,
+		segments: strings.Split(strings.Trim(pattern, "/"), "/"),
+		handler:  handler,
+	})
+}
+
+func (server *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	pathMatched := false
+	for _, candidate := range server.routes {
+		params, ok := matchRoute(candidate.segments, segments)
+		if !ok {
+			continue
+		}
+
+		pathMatched = true
+		if candidate.method != r.Method {
+			continue
+		}
+
+		candidate.handler(w, r, params)
+		return
+	}
+
+	if pathMatched {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	writeError(w, http.StatusNotFound, "not found")
+}
+
+func matchRoute(pattern []string, segments []string) (map[string]string, bool) {
+	if len(pattern) != len(segments) {
+		return nil, false
+	}
+
+	params := map[string]string{}
+	for i, segment := range pattern {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			params[segment[1:len(segment)-1]] = segments[i]
+			continue
+		}
+
+		if segment != segments[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+func (server *Server) generateID() int {
+	server.nextID++
+
+	return server.nextID
+}
+
+func (server *Server) generateUID(prefix string) string {
+	return fmt.Sprintf("%s-%d", prefix, server.generateID())
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"message": message})
+}
+
+func writeStatusError(w http.ResponseWriter, httpStatus int, status string, message string) {
+	writeJSON(w, httpStatus, map[string]string{"message": message, "status": status})
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, payload interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(payload); err != nil {
+		writeError(w, http.StatusBadRequest, "bad request data: "+err.Error())
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,959 @@
+package grabanatest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/K-Phoen/grabana"
+	"github.com/K-Phoen/grabana/alertmanager"
+	"github.com/K-Phoen/grabana/alertmanager/email"
+	"github.com/K-Phoen/grabana/alertmanager/slack"
+	"github.com/K-Phoen/grabana/dashboard"
+	"github.com/K-Phoen/grabana/librarypanel"
+	alert "github.com/K-Phoen/grabana/ngalert"
+	"github.com/K-Phoen/grabana/ngalert/query"
+	"github.com/K-Phoen/grabana/playlist"
+	"github.com/K-Phoen/grabana/row"
+	"github.com/K-Phoen/grabana/rulegroup"
+	"github.com/K-Phoen/grabana/timeseries"
+	"github.com/stretchr/testify/require"
+)
+
+func dashboardWithAlerts(t *testing.T, alertTitles ...string) dashboard.Builder {
+	t.Helper()
+
+	timeseriesOpts := []timeseries.Option{
+		timeseries.DataSource("Prometheus"),
+		timeseries.WithPrometheusTarget("sum(rate(errors_total[5m]))"),
+	}
+	for _, title := range alertTitles {
+		timeseriesOpts = append(timeseriesOpts, timeseries.Alert(title,
+			alert.Query("A", query.Expr("sum(rate(errors_total[5m])) > 1"), query.AlertCondition()),
+		))
+	}
+
+	builder, err := dashboard.New("Service",
+		dashboard.Tags([]string{"generated"}),
+		dashboard.Row("Errors", row.WithTimeSeries("Errors", timeseriesOpts...)),
+	)
+	require.NoError(t, err)
+
+	return builder
+}
+
+func TestUpsertDashboardFlow(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+	server.AddDatasource("Prometheus", "prometheus", true)
+
+	client := server.Client()
+
+	folder, err := client.FindOrCreateFolder(ctx, "Team")
+	req.NoError(err)
+
+	_, err = client.UpsertDashboard(ctx, folder, dashboardWithAlerts(t, "Too many errors", "Way too many errors"))
+	req.NoError(err)
+
+	dashboards := server.Dashboards()
+	req.Len(dashboards, 1)
+	req.Equal("Service", dashboards[0].Title)
+	req.Equal(folder.UID, dashboards[0].FolderUID)
+	req.Equal([]string{"generated"}, dashboards[0].Tags)
+	req.Equal(1, dashboards[0].Version)
+
+	board, err := dashboards[0].Board()
+	req.NoError(err)
+	req.Equal(dashboards[0].UID, board.UID)
+
+	rules := server.AlertRules()
+	req.Len(rules, 2)
+	req.Equal(folder.UID, rules[0].FolderUID)
+	req.Equal("Service", rules[0].RuleGroup)
+	req.Equal(dashboards[0].UID, rules[0].Annotations["__dashboardUid__"])
+
+	// applying the dashboard again updates it in place, and removes stale alerts
+	_, err = client.UpsertDashboard(ctx, folder, dashboardWithAlerts(t, "Too many errors"))
+	req.NoError(err)
+
+	dashboards = server.Dashboards()
+	req.Len(dashboards, 1)
+	req.Equal(2, dashboards[0].Version)
+
+	rules = server.AlertRules()
+	req.Len(rules, 1)
+	req.Equal("Too many errors", rules[0].Title)
+}
+
+func TestDeleteFolderFlow(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+	server.AddDatasource("Prometheus", "prometheus", true)
+
+	client := server.Client()
+	folder := server.AddFolder("Team")
+
+	_, err := client.UpsertDashboard(ctx, &folder, dashboardWithAlerts(t, "Too many errors"))
+	req.NoError(err)
+
+	err = client.DeleteFolder(ctx, folder.UID, false)
+	req.Error(err)
+	req.Len(server.Folders(), 1)
+
+	err = client.DeleteFolder(ctx, folder.UID, true)
+	req.NoError(err)
+
+	req.Empty(server.Folders())
+	req.Empty(server.Dashboards())
+	req.Empty(server.AlertRules())
+
+	_, err = client.GetFolderByTitle(ctx, "Team")
+	req.ErrorIs(err, grabana.ErrFolderNotFound)
+}
+
+func TestNestedFoldersFlow(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+
+	client := server.Client()
+
+	postgres, err := client.EnsureFolderPath(ctx, "Platform/Databases/Postgres")
+	req.NoError(err)
+	req.Len(server.Folders(), 3)
+
+	// ensuring an existing path does not create anything
+	again, err := client.EnsureFolderPath(ctx, "Platform/Databases/Postgres")
+	req.NoError(err)
+	req.Equal(postgres.UID, again.UID)
+	req.Len(server.Folders(), 3)
+
+	mysql, err := client.EnsureFolderPath(ctx, "Platform/Databases/MySQL")
+	req.NoError(err)
+
+	builder, err := dashboard.New("Replication")
+	req.NoError(err)
+	_, err = client.UpsertDashboardInFolderPath(ctx, "Platform/Databases/Postgres", builder)
+	req.NoError(err)
+	req.Equal(postgres.UID, server.Dashboards()[0].FolderUID)
+
+	platform, err := client.GetFolderByPath(ctx, "Platform")
+	req.NoError(err)
+
+	tree, err := client.GetFolderTree(ctx, platform.UID)
+	req.NoError(err)
+	req.Len(tree.Children, 1)
+	req.Equal("Databases", tree.Children[0].Title)
+	req.Len(tree.Children[0].Children, 2)
+
+	// move MySQL to the top-level
+	moved, err := client.MoveFolder(ctx, mysql.UID, "")
+	req.NoError(err)
+	req.Empty(moved.ParentUID)
+
+	_, err = client.GetFolderByPath(ctx, "MySQL")
+	req.NoError(err)
+
+	// a folder can not be moved under its own descendants
+	_, err = client.MoveFolder(ctx, platform.UID, postgres.UID)
+	req.Error(err)
+
+	// deleting a folder deletes its subfolders and their dashboards
+	req.NoError(client.DeleteFolder(ctx, platform.UID, false))
+	req.Len(server.Folders(), 1)
+	req.Empty(server.Dashboards())
+}
+
+func TestDeleteDashboardFlow(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+	server.AddDatasource("Prometheus", "prometheus", true)
+
+	client := server.Client()
+	folder := server.AddFolder("Team")
+
+	created, err := client.UpsertDashboard(ctx, &folder, dashboardWithAlerts(t, "Too many errors"))
+	req.NoError(err)
+
+	req.NoError(client.DeleteDashboard(ctx, created.UID))
+	req.Empty(server.Dashboards())
+	req.Empty(server.AlertRules())
+
+	err = client.DeleteDashboard(ctx, created.UID)
+	req.True(grabana.IsNotFound(err))
+}
+
+func TestDashboardsAreSearchable(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+
+	client := server.Client()
+	folder := server.AddFolder("Team")
+	other := server.AddFolder("Other")
+
+	builder, err := dashboard.New("Service")
+	req.NoError(err)
+
+	_, err = client.UpsertDashboard(ctx, &folder, builder)
+	req.NoError(err)
+
+	found, err := client.GetDashboardByTitle(ctx, "service")
+	req.NoError(err)
+	req.Equal("Team", found.FolderTitle)
+
+	inFolder, err := client.ListDashboardsInFolder(ctx, &other)
+	req.NoError(err)
+	req.Empty(inFolder)
+}
+
+func TestDashboardsSearchIsPaginated(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+
+	client := server.Client()
+	folder := server.AddFolder("Team")
+
+	for i := 0; i < 5; i++ {
+		tags := []string{"generated"}
+		if i%2 == 0 {
+			tags = append(tags, "even")
+		}
+
+		builder, err := dashboard.New(fmt.Sprintf("Service %d", i), dashboard.Tags(tags))
+		req.NoError(err)
+		_, err = client.UpsertDashboard(ctx, &folder, builder)
+		req.NoError(err)
+	}
+
+	dashboards, err := client.SearchDashboards(ctx, grabana.SearchDashboardsQuery{Limit: 2})
+	req.NoError(err)
+	req.Len(dashboards, 5)
+
+	dashboards, err = client.SearchDashboards(ctx, grabana.SearchDashboardsQuery{Tags: []string{"generated", "even"}, Limit: 2})
+	req.NoError(err)
+	req.Len(dashboards, 3)
+
+	dashboards, err = client.SearchDashboards(ctx, grabana.SearchDashboardsQuery{DashboardUIDs: []string{dashboards[0].UID}})
+	req.NoError(err)
+	req.Len(dashboards, 1)
+
+	other := server.AddFolder("Other team")
+	builder, err := dashboard.New("Other service")
+	req.NoError(err)
+	_, err = client.UpsertDashboard(ctx, &other, builder)
+	req.NoError(err)
+
+	dashboards, err = client.SearchDashboards(ctx, grabana.SearchDashboardsQuery{FolderUIDs: []string{folder.UID, other.UID}})
+	req.NoError(err)
+	req.Len(dashboards, 6)
+}
+
+func TestDatasourcesFlow(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+
+	client := server.Client()
+	existing := server.AddDatasource("Prometheus", "prometheus", true)
+
+	uid, err := client.GetDatasourceUIDByName(ctx, "Prometheus")
+	req.NoError(err)
+	req.Equal(existing.UID, uid)
+
+	datasources, err := client.ListDatasources(ctx)
+	req.NoError(err)
+	req.Len(datasources, 1)
+	req.Equal("prometheus", datasources[0].Type)
+
+	datasource, err := client.GetDatasourceByUID(ctx, existing.UID)
+	req.NoError(err)
+	req.Equal("Prometheus", datasource.Name)
+
+	health, err := client.CheckDatasourceHealth(ctx, existing.UID)
+	req.NoError(err)
+	req.True(health.Healthy())
+
+	server.SetDatasourceHealth("Prometheus", false, "connection refused")
+	health, err = client.CheckDatasourceHealth(ctx, existing.UID)
+	req.NoError(err)
+	req.False(health.Healthy())
+	req.Equal("connection refused", health.Message)
+
+	server.DisableDatasourceHealthCheck("Prometheus")
+	_, err = client.CheckDatasourceHealth(ctx, existing.UID)
+	req.ErrorIs(err, grabana.ErrHealthCheckNotSupported)
+
+	req.NoError(client.DeleteDatasource(ctx, "Prometheus"))
+
+	_, err = client.CheckDatasourceHealth(ctx, existing.UID)
+	req.ErrorIs(err, grabana.ErrDatasourceNotFound)
+	req.Empty(server.Datasources())
+
+	err = client.DeleteDatasource(ctx, "Prometheus")
+	req.ErrorIs(err, grabana.ErrDatasourceNotFound)
+}
+
+func TestAPIKeysFlow(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+
+	client := server.Client()
+
+	key, err := client.CreateAPIKey(ctx, grabana.CreateAPIKeyRequest{Name: "ci", Role: grabana.EditorRole})
+	req.NoError(err)
+	req.NotEmpty(key)
+
+	keys := server.APIKeys()
+	req.Len(keys, 1)
+	req.Equal("Editor", keys[0].Role)
+
+	_, err = client.CreateAPIKey(ctx, grabana.CreateAPIKeyRequest{Name: "ci", Role: grabana.EditorRole})
+	req.True(grabana.IsConflict(err))
+
+	req.NoError(client.DeleteAPIKeyByName(ctx, "ci"))
+	req.Empty(server.APIKeys())
+}
+
+func TestAlertManagerConfigIsStored(t *testing.T) {
+	req := require.New(t)
+
+	server := NewServer()
+	defer server.Close()
+
+	manager := alertmanager.New(alertmanager.DefaultContactPoint("team"))
+
+	req.NoError(server.Client().ConfigureAlertManager(context.Background(), manager))
+	req.Contains(string(server.AlertManagerConfig()), `"receiver": "team"`)
+}
+
+func TestUnknownEndpointsAnswerWithNotFound(t *testing.T) {
+	req := require.New(t)
+
+	server := NewServer()
+	defer server.Close()
+
+	_, err := server.Client().GetDashboardByUID(context.Background(), "unknown")
+
+	req.ErrorIs(err, grabana.ErrDashboardNotFound)
+}
+
+func TestUpsertDashboardEnforcesPermissions(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+
+	client := server.Client()
+	folder := server.AddFolder("Team")
+
+	req.NoError(client.SetFolderPermissions(ctx, folder.UID, []grabana.Permission{
+		{Role: grabana.OrgRoleViewer, Permission: dashboard.PermissionView},
+	}))
+
+	builder, err := dashboard.New("Service", dashboard.Permissions(
+		dashboard.TeamPermission(4, dashboard.PermissionEdit),
+	))
+	req.NoError(err)
+
+	created, err := client.UpsertDashboard(ctx, &folder, builder)
+	req.NoError(err)
+
+	req.Equal([]grabana.Permission{{TeamID: 4, Permission: dashboard.PermissionEdit}}, server.DashboardPermissions(created.UID))
+
+	permissions, err := client.GetDashboardPermissions(ctx, created.UID)
+	req.NoError(err)
+	req.Equal([]grabana.Permission{
+		{Role: grabana.OrgRoleViewer, Permission: dashboard.PermissionView, Inherited: true},
+		{TeamID: 4, Permission: dashboard.PermissionEdit},
+	}, permissions)
+}
+
+func TestDashboardVersionsFlow(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+	server.AddDatasource("Prometheus", "prometheus", true)
+
+	client := server.Client()
+	folder := server.AddFolder("Team")
+
+	builder, err := dashboard.New("Service", dashboard.Tags([]string{"v1"}))
+	req.NoError(err)
+	created, err := client.UpsertDashboard(ctx, &folder, builder, grabana.CommitMessage("sha-1"))
+	req.NoError(err)
+
+	builder, err = dashboard.New("Service", dashboard.Tags([]string{"v2"}))
+	req.NoError(err)
+	_, err = client.UpsertDashboard(ctx, &folder, builder, grabana.CommitMessage("sha-2"))
+	req.NoError(err)
+
+	versions, err := client.ListDashboardVersions(ctx, created.UID)
+	req.NoError(err)
+	req.Len(versions, 2)
+	req.Equal(2, versions[0].Version)
+	req.Equal("sha-2", versions[0].Message)
+	req.Equal("sha-1", versions[1].Message)
+
+	diff, err := client.DiffDashboardVersions(ctx, created.UID, 1, 2)
+	req.NoError(err)
+	req.True(diff.HasChanges())
+
+	_, err = client.RestoreDashboardVersion(ctx, created.UID, 1)
+	req.NoError(err)
+
+	dashboards := server.Dashboards()
+	req.Len(dashboards, 1)
+	req.Equal(3, dashboards[0].Version)
+	req.Equal([]string{"v1"}, dashboards[0].Tags)
+
+	_, err = client.GetDashboardVersion(ctx, created.UID, 42)
+	req.ErrorIs(err, grabana.ErrDashboardVersionNotFound)
+}
+
+func TestOptimisticConcurrencyFlow(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+
+	client := server.Client()
+	folder := server.AddFolder("Team")
+
+	builder, err := dashboard.New("Service")
+	req.NoError(err)
+	_, err = client.UpsertDashboard(ctx, &folder, builder, grabana.OptimisticConcurrency())
+	req.NoError(err)
+
+	// the dashboard was saved as version 1, expecting anything else fails
+	_, err = client.UpsertDashboard(ctx, &folder, builder, grabana.ExpectedVersion(3))
+	req.ErrorIs(err, grabana.ErrDashboardVersionMismatch)
+	req.True(grabana.IsPreconditionFailed(err))
+
+	_, err = client.UpsertDashboard(ctx, &folder, builder, grabana.ExpectedVersion(1))
+	req.NoError(err)
+
+	_, err = client.UpsertDashboard(ctx, &folder, builder, grabana.OptimisticConcurrency())
+	req.NoError(err)
+	req.Equal(3, server.Dashboards()[0].Version)
+}
+
+func TestManualEditsCanBeProtected(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+	server.SetCurrentUser("sa-grabana")
+
+	client := server.Client()
+	folder := server.AddFolder("Team")
+
+	builder, err := dashboard.New("Service")
+	req.NoError(err)
+	_, err = client.UpsertDashboard(ctx, &folder, builder, grabana.RefuseManualEdits())
+	req.NoError(err)
+
+	// dashboards last saved by the provisioning identity can be updated
+	_, err = client.UpsertDashboard(ctx, &folder, builder, grabana.RefuseManualEdits())
+	req.NoError(err)
+
+	// someone edits the dashboard from the UI
+	server.SetCurrentUser("jane")
+	_, err = client.UpsertDashboard(ctx, &folder, builder)
+	req.NoError(err)
+	server.SetCurrentUser("sa-grabana")
+
+	_, err = client.UpsertDashboard(ctx, &folder, builder, grabana.RefuseManualEdits())
+	req.ErrorIs(err, grabana.ErrDashboardEditedManually)
+
+	_, err = client.UpsertDashboard(ctx, &folder, builder, grabana.RefuseManualEdits("sa-grabana", "jane"))
+	req.NoError(err)
+	req.Equal(4, server.Dashboards()[0].Version)
+}
+
+func TestAnnotationsFlow(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+
+	client := server.Client()
+	folder := server.AddFolder("Team")
+
+	builder, err := dashboard.New("Service")
+	req.NoError(err)
+	dash, err := client.UpsertDashboard(ctx, &folder, builder)
+	req.NoError(err)
+
+	deploy, err := client.CreateAnnotation(ctx, grabana.Annotation{
+		DashboardUID: dash.UID,
+		Time:         time.UnixMilli(1000),
+		Tags:         []string{"deploy"},
+		Text:         "Deployed v1",
+	})
+	req.NoError(err)
+
+	_, err = client.CreateAnnotation(ctx, grabana.Annotation{
+		Time:    time.UnixMilli(2000),
+		TimeEnd: time.UnixMilli(3000),
+		Tags:    []string{"maintenance"},
+		Text:    "Maintenance window",
+	})
+	req.NoError(err)
+
+	annotations, err := client.ListAnnotations(ctx, grabana.AnnotationQuery{DashboardUID: dash.UID})
+	req.NoError(err)
+	req.Len(annotations, 1)
+	req.Equal("Deployed v1", annotations[0].Text)
+
+	annotations, err = client.ListAnnotations(ctx, grabana.AnnotationQuery{From: time.UnixMilli(1500)})
+	req.NoError(err)
+	req.Len(annotations, 1)
+	req.Equal("Maintenance window", annotations[0].Text)
+
+	deploy.Text = "Deployed v1 (rolled back)"
+	req.NoError(client.UpdateAnnotation(ctx, *deploy))
+	req.NoError(client.DeleteAnnotation(ctx, annotations[0].ID))
+
+	stored := server.Annotations()
+	req.Len(stored, 1)
+	req.Equal("Deployed v1 (rolled back)", stored[0].Text)
+
+	req.ErrorIs(client.DeleteAnnotation(ctx, annotations[0].ID), grabana.ErrAnnotationNotFound)
+}
+
+func TestLibraryPanelsFlow(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+
+	client := server.Client()
+	folder := server.AddFolder("Team")
+
+	panel, err := timeseries.New("SLO burn rate", timeseries.WithPrometheusTarget("slo:burn_rate"))
+	req.NoError(err)
+
+	_, err = client.UpsertLibraryPanel(ctx, &folder, "slo-burn-rate", panel.Builder)
+	req.NoError(err)
+
+	// upserting again updates the existing library panel
+	libraryPanel, err := client.UpsertLibraryPanel(ctx, &folder, "slo-burn-rate", panel.Builder)
+	req.NoError(err)
+	req.Equal(2, libraryPanel.Version)
+	req.Len(server.LibraryPanels(), 1)
+
+	builder, err := dashboard.New("Service",
+		dashboard.Row("SLO", row.WithLibraryPanel("slo-burn-rate", librarypanel.Name("SLO burn rate"))),
+	)
+	req.NoError(err)
+	_, err = client.UpsertDashboard(ctx, &folder, builder)
+	req.NoError(err)
+
+	// library panels used by dashboards can not be deleted
+	err = client.DeleteLibraryPanel(ctx, "slo-burn-rate")
+	req.True(grabana.IsForbidden(err))
+
+	req.NoError(client.DeleteDashboard(ctx, server.Dashboards()[0].UID))
+	req.NoError(client.DeleteLibraryPanel(ctx, "slo-burn-rate"))
+
+	_, err = client.GetLibraryPanelByUID(ctx, "slo-burn-rate")
+	req.ErrorIs(err, grabana.ErrLibraryPanelNotFound)
+}
+
+func TestTeamsFlow(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+	server.AddUser("jane", "jane@example.com")
+	server.AddUser("john", "john@example.com")
+
+	client := server.Client()
+
+	team, err := client.FindOrCreateTeam(ctx, "Platform")
+	req.NoError(err)
+
+	again, err := client.FindOrCreateTeam(ctx, "Platform")
+	req.NoError(err)
+	req.Equal(team.ID, again.ID)
+	req.Len(server.Teams(), 1)
+
+	req.NoError(client.AddTeamMember(ctx, team.ID, "jane"))
+	req.NoError(client.AddTeamMember(ctx, team.ID, "john@example.com"))
+	req.ErrorIs(client.AddTeamMember(ctx, team.ID, "unknown"), grabana.ErrUserNotFound)
+	req.ElementsMatch([]string{"jane", "john"}, server.TeamMembers(team.ID))
+
+	req.NoError(client.RemoveTeamMember(ctx, team.ID, "jane"))
+
+	members, err := client.ListTeamMembers(ctx, team.ID)
+	req.NoError(err)
+	req.Len(members, 1)
+	req.Equal("john", members[0].Login)
+
+	req.NoError(client.SetTeamPreferences(ctx, team.ID, grabana.TeamPreferences{Theme: "light", Timezone: "utc"}))
+	preferences, err := client.GetTeamPreferences(ctx, team.ID)
+	req.NoError(err)
+	req.Equal("light", preferences.Theme)
+	req.Equal("utc", preferences.Timezone)
+
+	req.NoError(client.DeleteTeam(ctx, team.ID))
+	_, err = client.GetTeamByName(ctx, "Platform")
+	req.ErrorIs(err, grabana.ErrTeamNotFound)
+}
+
+func TestSnapshotsFlow(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+
+	client := server.Client()
+	folder := server.AddFolder("Team")
+
+	builder, err := dashboard.New("Service")
+	req.NoError(err)
+	dash, err := client.UpsertDashboard(ctx, &folder, builder)
+	req.NoError(err)
+
+	snapshot, err := client.CreateSnapshotByUID(ctx, dash.UID, grabana.SnapshotName("INC-42"), grabana.SnapshotExpiry(time.Hour))
+	req.NoError(err)
+
+	snapshots, err := client.ListSnapshots(ctx)
+	req.NoError(err)
+	req.Len(snapshots, 1)
+	req.Equal("INC-42", snapshots[0].Name)
+	req.WithinDuration(time.Now().Add(time.Hour), snapshots[0].Expires, time.Minute)
+
+	board, err := client.GetSnapshot(ctx, snapshot.Key)
+	req.NoError(err)
+	req.Equal("Service", board.Title)
+
+	req.NoError(client.DeleteSnapshot(ctx, snapshot.Key))
+	req.Empty(server.Snapshots())
+
+	_, err = client.GetSnapshot(ctx, snapshot.Key)
+	req.ErrorIs(err, grabana.ErrSnapshotNotFound)
+}
+
+func TestPlaylistsFlow(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+
+	client := server.Client()
+
+	builder, err := playlist.New("NOC", playlist.DashboardsByTag("noc"))
+	req.NoError(err)
+	created, err := client.UpsertPlaylist(ctx, builder)
+	req.NoError(err)
+
+	// upserting by name updates the playlist in place
+	builder, err = playlist.New("NOC", playlist.Interval("1m"), playlist.DashboardsByUID("overview"))
+	req.NoError(err)
+	updated, err := client.UpsertPlaylist(ctx, builder)
+	req.NoError(err)
+	req.Equal(created.UID, updated.UID)
+
+	playlists := server.Playlists()
+	req.Len(playlists, 1)
+	req.Equal("1m", playlists[0].Interval)
+	req.Equal([]playlist.Item{{Type: playlist.ItemDashboardByUID, Value: "overview"}}, playlists[0].Items)
+
+	// an explicit UID takes precedence over the name
+	builder, err = playlist.New("NOC", playlist.UID("noc-secondary"))
+	req.NoError(err)
+	_, err = client.UpsertPlaylist(ctx, builder)
+	req.NoError(err)
+	req.Len(server.Playlists(), 2)
+
+	req.NoError(client.DeletePlaylist(ctx, created.UID))
+	_, err = client.GetPlaylistByUID(ctx, created.UID)
+	req.ErrorIs(err, grabana.ErrPlaylistNotFound)
+}
+
+func TestAlertingProvisioningFlow(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+
+	client := server.Client()
+
+	// two teams own their contact point without stepping on each other
+	req.NoError(client.UpsertContactPoint(ctx, alertmanager.ContactPoint("team-a", email.To([]string{"a@example.com"}))))
+	req.NoError(client.UpsertContactPoint(ctx, alertmanager.ContactPoint("team-b", slack.Webhook("https://hooks.slack.com/b"))))
+
+	teamA, err := client.GetContactPointsByName(ctx, "team-a")
+	req.NoError(err)
+	req.Len(teamA, 1)
+
+	// upserting again updates the existing integration in place
+	req.NoError(client.UpsertContactPoint(ctx, alertmanager.ContactPoint("team-a", email.To([]string{"oncall-a@example.com"}))))
+	updated, err := client.GetContactPointsByName(ctx, "team-a")
+	req.NoError(err)
+	req.Len(updated, 1)
+	req.Equal(teamA[0].UID, updated[0].UID)
+	req.Equal("oncall-a@example.com", updated[0].Settings["addresses"])
+
+	contactPoints, err := client.ListContactPoints(ctx)
+	req.NoError(err)
+	req.Len(contactPoints, 3)
+
+	req.NoError(client.UpsertMessageTemplate(ctx, "team-a", `{{ define "team-a" }}alert{{ end }}`))
+	template, err := client.GetMessageTemplate(ctx, "team-a")
+	req.NoError(err)
+	req.Equal(`{{ define "team-a" }}alert{{ end }}`, template.Template)
+
+	manager := alertmanager.New(
+		alertmanager.DefaultContactPoint("team-b"),
+		alertmanager.Routing(alertmanager.Policy("team-a", alertmanager.TagEq("team", "a"))),
+	)
+	req.NoError(client.SetNotificationPolicies(ctx, manager))
+
+	root, err := client.GetNotificationPolicies(ctx)
+	req.NoError(err)
+	req.Equal("team-b", root.Receiver)
+	req.Len(root.Routes, 1)
+	req.Equal("team-a", root.Routes[0].Receiver)
+
+	// contact points used by the policy tree can not be deleted
+	err = client.DeleteContactPoint(ctx, "team-a")
+	req.True(grabana.IsConflict(err))
+
+	req.NoError(client.ResetNotificationPolicies(ctx))
+	req.NoError(client.DeleteContactPoint(ctx, "team-a"))
+	_, err = client.GetContactPointsByName(ctx, "team-a")
+	req.ErrorIs(err, grabana.ErrContactPointNotFound)
+
+	req.NoError(client.DeleteMessageTemplate(ctx, "team-a"))
+	req.Empty(server.MessageTemplates())
+}
+
+func TestMuteTimingsFlow(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+
+	client := server.Client()
+
+	offHours := alertmanager.MuteTiming("off-hours",
+		alertmanager.Period(alertmanager.Times("18:00", "24:00"), alertmanager.Location("Europe/Paris")),
+	)
+	req.NoError(client.UpsertMuteTiming(ctx, offHours))
+
+	offHours = alertmanager.MuteTiming("off-hours",
+		alertmanager.Period(alertmanager.Weekdays("saturday", "sunday")),
+	)
+	req.NoError(client.UpsertMuteTiming(ctx, offHours))
+
+	timings, err := client.ListMuteTimings(ctx)
+	req.NoError(err)
+	req.Len(timings, 1)
+	req.Equal([]string{"saturday", "sunday"}, timings[0].TimeIntervals[0].Weekdays)
+
+	// policies can only refer to known mute timings
+	manager := alertmanager.New(
+		alertmanager.DefaultContactPoint("grafana-default-email"),
+		alertmanager.Routing(
+			alertmanager.Policy("grafana-default-email", alertmanager.TagEq("team", "a"), alertmanager.MuteTimings("maintenance")),
+		),
+	)
+	err = client.SetNotificationPolicies(ctx, manager)
+	req.Error(err)
+
+	manager = alertmanager.New(
+		alertmanager.DefaultContactPoint("grafana-default-email"),
+		alertmanager.Routing(
+			alertmanager.Policy("grafana-default-email", alertmanager.TagEq("team", "a"), alertmanager.MuteTimings("off-hours")),
+		),
+	)
+	req.NoError(client.SetNotificationPolicies(ctx, manager))
+
+	root, err := client.GetNotificationPolicies(ctx)
+	req.NoError(err)
+	req.Equal([]string{"off-hours"}, root.Routes[0].MuteTimeIntervals)
+
+	// mute timings used by the policy tree can not be deleted
+	err = client.DeleteMuteTiming(ctx, "off-hours")
+	req.True(grabana.IsConflict(err))
+
+	req.NoError(client.ResetNotificationPolicies(ctx))
+	req.NoError(client.DeleteMuteTiming(ctx, "off-hours"))
+
+	_, err = client.GetMuteTiming(ctx, "off-hours")
+	req.ErrorIs(err, grabana.ErrMuteTimingNotFound)
+}
+
+func TestNestedNotificationPoliciesFlow(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+
+	client := server.Client()
+
+	req.NoError(client.UpsertContactPoint(ctx, alertmanager.ContactPoint("team-a", email.To([]string{"a@example.com"}))))
+	req.NoError(client.UpsertContactPoint(ctx, alertmanager.ContactPoint("team-a-pager", slack.Webhook("https://hooks.slack.com/a"))))
+
+	manager := alertmanager.New(
+		alertmanager.DefaultContactPoint("grafana-default-email"),
+		alertmanager.DefaultGroupWait("30s"),
+		alertmanager.Routing(
+			alertmanager.Policy("team-a",
+				alertmanager.TagEq("owner", "team-a"),
+				alertmanager.GroupBy("service"),
+				alertmanager.RepeatInterval("1h"),
+				alertmanager.Nested(
+					alertmanager.Policy("team-a-pager", alertmanager.TagEq("severity", "critical"), alertmanager.Continue()),
+				),
+			),
+		),
+	)
+	req.NoError(client.SetNotificationPolicies(ctx, manager))
+
+	root, err := client.GetNotificationPolicies(ctx)
+	req.NoError(err)
+	req.Equal("30s", root.GroupWait)
+	req.Equal([]string{"service"}, root.Routes[0].GroupBy)
+	req.Equal("1h", root.Routes[0].RepeatInterval)
+	req.Len(root.Routes[0].Routes, 1)
+	req.Equal("team-a-pager", root.Routes[0].Routes[0].Receiver)
+	req.True(root.Routes[0].Routes[0].Continue)
+
+	// nested receivers are checked by Grafana too
+	req.NoError(client.ResetNotificationPolicies(ctx))
+	req.NoError(client.DeleteContactPoint(ctx, "team-a-pager"))
+	req.Error(client.SetNotificationPolicies(ctx, manager))
+}
+
+func TestRuleGroupsFlow(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+
+	server.AddDatasource("Prometheus", "prometheus", true)
+	folder := server.AddFolder("Infrastructure")
+	client := server.Client()
+
+	rule := func(title string) *alert.Alert {
+		return alert.New(title, alert.Query("A", query.Datasource("Prometheus"), query.Expr("up == 0"), query.AlertCondition()))
+	}
+	group := func(rules ...*alert.Alert) rulegroup.Builder {
+		builder, err := rulegroup.New("Nodes", rulegroup.FolderUID(folder.UID), rulegroup.Interval(30*time.Second), rulegroup.Rules(rules...))
+		req.NoError(err)
+
+		return builder
+	}
+
+	req.NoError(client.UpsertRuleGroup(ctx, group(rule("Node down"), rule("Disk full"))))
+
+	stored, err := client.GetRuleGroup(ctx, folder.UID, "Nodes")
+	req.NoError(err)
+	req.Equal(int64(30), stored.Interval)
+	req.Len(stored.Rules, 2)
+	req.Equal("Node down", stored.Rules[0].Title)
+	req.Equal("Disk full", stored.Rules[1].Title)
+	nodeDownUID := stored.Rules[0].Uid
+
+	// rules are kept by title, and the ones no longer defined are removed
+	req.NoError(client.UpsertRuleGroup(ctx, group(rule("Memory pressure"), rule("Node down"))))
+
+	stored, err = client.GetRuleGroup(ctx, folder.UID, "Nodes")
+	req.NoError(err)
+	req.Len(stored.Rules, 2)
+	req.Equal("Memory pressure", stored.Rules[0].Title)
+	req.Equal("Node down", stored.Rules[1].Title)
+	req.Equal(nodeDownUID, stored.Rules[1].Uid)
+	req.Len(server.AlertRules(), 2)
+
+	// dashboards do not touch standalone rule groups
+	_, err = client.UpsertDashboard(ctx, &grabana.Folder{UID: folder.UID}, dashboardWithAlerts(t))
+	req.NoError(err)
+	req.Len(server.AlertRules(), 2)
+
+	req.NoError(client.DeleteRuleGroup(ctx, folder.UID, "Nodes"))
+	req.Empty(server.AlertRules())
+
+	_, err = client.GetRuleGroup(ctx, folder.UID, "Nodes")
+	req.ErrorIs(err, grabana.ErrRuleGroupNotFound)
+	req.ErrorIs(client.DeleteRuleGroup(ctx, folder.UID, "Nodes"), grabana.ErrRuleGroupNotFound)
+}
+
+func TestDashboardUpsertsAreRetried(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+
+	target, err := url.Parse(server.URL)
+	req.NoError(err)
+	proxy := httputil.NewSingleHostReverseProxy(target)
+
+	upsertAttempts := 0
+	flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/api/dashboards/db" {
+			upsertAttempts++
+			if upsertAttempts == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		}
+
+		proxy.ServeHTTP(w, r)
+	}))
+	defer flaky.Close()
+
+	client := grabana.NewClient(http.DefaultClient, flaky.URL, grabana.WithRetries(2, time.Millisecond, time.Millisecond))
+	folder := server.AddFolder("Team")
+
+	_, err = client.UpsertDashboard(ctx, &folder, dashboardWithAlerts(t))
+	req.NoError(err)
+
+	req.Equal(2, upsertAttempts)
+	req.Len(server.Dashboards(), 1)
+}
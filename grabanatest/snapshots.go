@@ -0,0 +1,138 @@
+package grabanatest
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Snapshot is a dashboard snapshot stored by the fake server.
+type Snapshot struct {
+	ID        int             `json:"id"`
+	Key       string          `json:"key"`
+	DeleteKey string          `json:"-"`
+	Name      string          `json:"name"`
+	External  bool            `json:"external"`
+	Expires   time.Time       `json:"expires"`
+	Created   time.Time       `json:"created"`
+	Model     json.RawMessage `json:"-"`
+}
+
+// Snapshots returns a copy of the snapshots currently stored.
+func (server *Server) Snapshots() []Snapshot {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	snapshots := make([]Snapshot, 0, len(server.snapshots))
+	for _, snapshot := range server.snapshots {
+		snapshots = append(snapshots, *snapshot)
+	}
+
+	return snapshots
+}
+
+func (server *Server) registerSnapshotRoutes() {
+	server.handle(http.MethodPost, "/api/snapshots", server.postSnapshot)
+	server.handle(http.MethodGet, "/api/dashboard/snapshots", server.listSnapshots)
+	server.handle(http.MethodGet, "/api/snapshots/{key}", server.getSnapshot)
+	server.handle(http.MethodDelete, "/api/snapshots/{key}", server.deleteSnapshot)
+}
+
+func (server *Server) snapshotByKey(key string) *Snapshot {
+	for _, snapshot := range server.snapshots {
+		if snapshot.Key == key {
+			return snapshot
+		}
+	}
+
+	return nil
+}
+
+func (server *Server) postSnapshot(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	request := struct {
+		Dashboard json.RawMessage `json:"dashboard"`
+		Name      string          `json:"name"`
+		Expires   int64           `json:"expires"`
+		External  bool            `json:"external"`
+	}{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	if len(request.Dashboard) == 0 {
+		writeError(w, http.StatusBadRequest, "dashboard is required")
+		return
+	}
+	if request.External {
+		writeError(w, http.StatusForbidden, "External dashboard creation is disabled")
+		return
+	}
+
+	now := time.Now()
+	expires := now.AddDate(50, 0, 0)
+	if request.Expires > 0 {
+		expires = now.Add(time.Duration(request.Expires) * time.Second)
+	}
+
+	snapshot := &Snapshot{
+		ID:        server.generateID(),
+		Key:       server.generateUID("snapshot"),
+		DeleteKey: server.generateUID("delete"),
+		Name:      request.Name,
+		Expires:   expires,
+		Created:   now,
+		Model:     request.Dashboard,
+	}
+	server.snapshots = append(server.snapshots, snapshot)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":        snapshot.ID,
+		"key":       snapshot.Key,
+		"deleteKey": snapshot.DeleteKey,
+		"url":       server.URL + "/dashboard/snapshot/" + snapshot.Key,
+		"deleteUrl": server.URL + "/api/snapshots-delete/" + snapshot.DeleteKey,
+	})
+}
+
+func (server *Server) listSnapshots(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	snapshots := make([]Snapshot, 0, len(server.snapshots))
+	for _, snapshot := range server.snapshots {
+		snapshots = append(snapshots, *snapshot)
+	}
+
+	writeJSON(w, http.StatusOK, snapshots)
+}
+
+func (server *Server) getSnapshot(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	snapshot := server.snapshotByKey(params["key"])
+	if snapshot == nil || snapshot.Expires.Before(time.Now()) {
+		writeError(w, http.StatusNotFound, "Dashboard snapshot not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"meta":      map[string]interface{}{"isSnapshot": true, "created": snapshot.Created, "expires": snapshot.Expires},
+		"dashboard": snapshot.Model,
+	})
+}
+
+func (server *Server) deleteSnapshot(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	snapshot := server.snapshotByKey(params["key"])
+	if snapshot == nil {
+		writeError(w, http.StatusNotFound, "Failed to get dashboard snapshot")
+		return
+	}
+
+	snapshots := server.snapshots[:0]
+	for _, candidate := range server.snapshots {
+		if candidate != snapshot {
+			snapshots = append(snapshots, candidate)
+		}
+	}
+	server.snapshots = snapshots
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":      snapshot.ID,
+		"message": "Snapshot deleted. It might take an hour before it's cleared from any CDN caches.",
+	})
+}
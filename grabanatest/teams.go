@@ -0,0 +1,288 @@
+package grabanatest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/K-Phoen/grabana"
+)
+
+// Team is a team stored by the fake server.
+type Team struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	MemberCount int    `json:"memberCount"`
+
+	members     []uint
+	preferences grabana.TeamPreferences
+}
+
+// Teams returns a copy of the teams currently stored.
+func (server *Server) Teams() []Team {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	teams := make([]Team, 0, len(server.teams))
+	for _, team := range server.teams {
+		teams = append(teams, *team)
+	}
+
+	return teams
+}
+
+// TeamMembers returns the logins of the members of the given team.
+func (server *Server) TeamMembers(teamID uint) []string {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	team := server.teamByID(strconv.Itoa(int(teamID)))
+	if team == nil {
+		return nil
+	}
+
+	logins := make([]string, 0, len(team.members))
+	for _, userID := range team.members {
+		if user := server.userByID(userID); user != nil {
+			logins = append(logins, user.Login)
+		}
+	}
+
+	return logins
+}
+
+func (server *Server) registerTeamRoutes() {
+	server.handle(http.MethodGet, "/api/teams/search", server.searchTeams)
+	server.handle(http.MethodPost, "/api/teams", server.postTeam)
+	server.handle(http.MethodGet, "/api/teams/{id}", server.getTeam)
+	server.handle(http.MethodDelete, "/api/teams/{id}", server.deleteTeam)
+	server.handle(http.MethodGet, "/api/teams/{id}/members", server.listTeamMembers)
+	server.handle(http.MethodPost, "/api/teams/{id}/members", server.addTeamMember)
+	server.handle(http.MethodDelete, "/api/teams/{id}/members/{userId}", server.removeTeamMember)
+	server.handle(http.MethodGet, "/api/teams/{id}/preferences", server.getTeamPreferences)
+	server.handle(http.MethodPut, "/api/teams/{id}/preferences", server.putTeamPreferences)
+}
+
+func (server *Server) teamByID(idParam string) *Team {
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		return nil
+	}
+
+	for _, team := range server.teams {
+		if team.ID == uint(id) {
+			return team
+		}
+	}
+
+	return nil
+}
+
+func (server *Server) searchTeams(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	query := r.URL.Query()
+	name := query.Get("name")
+	search := strings.ToLower(query.Get("query"))
+
+	matching := []map[string]interface{}{}
+	for _, team := range server.teams {
+		if name != "" && team.Name != name {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(team.Name), search) {
+			continue
+		}
+
+		matching = append(matching, map[string]interface{}{
+			"id":          team.ID,
+			"name":        team.Name,
+			"email":       team.Email,
+			"memberCount": len(team.members),
+		})
+	}
+
+	page := paginate(matching, query.Get("perpage"), query.Get("page"))
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"totalCount": len(matching),
+		"teams":      page,
+	})
+}
+
+func (server *Server) postTeam(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	request := struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	if strings.TrimSpace(request.Name) == "" {
+		writeError(w, http.StatusBadRequest, "team name cannot be empty")
+		return
+	}
+
+	for _, team := range server.teams {
+		if team.Name == request.Name {
+			writeError(w, http.StatusConflict, "Team name taken")
+			return
+		}
+	}
+
+	team := &Team{ID: uint(server.generateID()), Name: request.Name, Email: request.Email}
+	server.teams = append(server.teams, team)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Team created",
+		"teamId":  team.ID,
+	})
+}
+
+func (server *Server) getTeam(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	team := server.teamByID(params["id"])
+	if team == nil {
+		writeError(w, http.StatusNotFound, "Team not found")
+		return
+	}
+
+	response := *team
+	response.MemberCount = len(team.members)
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (server *Server) deleteTeam(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	team := server.teamByID(params["id"])
+	if team == nil {
+		writeError(w, http.StatusNotFound, "Team not found")
+		return
+	}
+
+	teams := server.teams[:0]
+	for _, candidate := range server.teams {
+		if candidate != team {
+			teams = append(teams, candidate)
+		}
+	}
+	server.teams = teams
+
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Team deleted"})
+}
+
+func (server *Server) listTeamMembers(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	team := server.teamByID(params["id"])
+	if team == nil {
+		writeError(w, http.StatusNotFound, "Team not found")
+		return
+	}
+
+	members := []grabana.TeamMember{}
+	for _, userID := range team.members {
+		user := server.userByID(userID)
+		if user == nil {
+			continue
+		}
+
+		members = append(members, grabana.TeamMember{
+			TeamID: team.ID,
+			UserID: user.ID,
+			Login:  user.Login,
+			Email:  user.Email,
+			Name:   user.Name,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, members)
+}
+
+func (server *Server) addTeamMember(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	team := server.teamByID(params["id"])
+	if team == nil {
+		writeError(w, http.StatusNotFound, "Team not found")
+		return
+	}
+
+	request := struct {
+		UserID uint `json:"userId"`
+	}{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	if server.userByID(request.UserID) == nil {
+		writeError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	for _, userID := range team.members {
+		if userID == request.UserID {
+			writeError(w, http.StatusBadRequest, "User is already added to this team")
+			return
+		}
+	}
+
+	team.members = append(team.members, request.UserID)
+
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Member added to Team"})
+}
+
+func (server *Server) removeTeamMember(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	team := server.teamByID(params["id"])
+	if team == nil {
+		writeError(w, http.StatusNotFound, "Team not found")
+		return
+	}
+
+	userID, err := strconv.Atoi(params["userId"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "userId is invalid")
+		return
+	}
+
+	members := team.members[:0]
+	removed := false
+	for _, candidate := range team.members {
+		if candidate == uint(userID) {
+			removed = true
+			continue
+		}
+
+		members = append(members, candidate)
+	}
+	team.members = members
+
+	if !removed {
+		writeError(w, http.StatusNotFound, "Team member not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Team Member removed"})
+}
+
+func (server *Server) getTeamPreferences(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	team := server.teamByID(params["id"])
+	if team == nil {
+		writeError(w, http.StatusNotFound, "Team not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, team.preferences)
+}
+
+func (server *Server) putTeamPreferences(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	team := server.teamByID(params["id"])
+	if team == nil {
+		writeError(w, http.StatusNotFound, "Team not found")
+		return
+	}
+
+	preferences := grabana.TeamPreferences{}
+	if !decodeBody(w, r, &preferences) {
+		return
+	}
+
+	team.preferences = preferences
+
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Preferences updated"})
+}
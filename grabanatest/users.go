@@ -0,0 +1,89 @@
+package grabanatest
+
+import (
+	"net/http"
+	"strings"
+)
+
+// User is a user of the organization, as stored by the fake server.
+type User struct {
+	ID    uint   `json:"userId"`
+	Login string `json:"login"`
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+// AddUser creates a user in the organization, bypassing the HTTP API.
+func (server *Server) AddUser(login string, email string) User {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	user := &User{ID: uint(server.generateID()), Login: login, Email: email, Name: login}
+	server.users = append(server.users, user)
+
+	return *user
+}
+
+func (server *Server) userByID(id uint) *User {
+	for _, user := range server.users {
+		if user.ID == id {
+			return user
+		}
+	}
+
+	return nil
+}
+
+const defaultLogin = "admin"
+
+// SetCurrentUser changes the login of the user the following requests are
+// attributed to. It defaults to "admin".
+func (server *Server) SetCurrentUser(login string) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	server.currentLogin = login
+}
+
+func (server *Server) registerUserRoutes() {
+	server.handle(http.MethodGet, "/api/user", server.getCurrentUser)
+	server.handle(http.MethodGet, "/api/org/users", server.listCurrentOrgUsers)
+	server.handle(http.MethodGet, "/api/org/users/lookup", server.lookupUsers)
+}
+
+func (server *Server) getCurrentUser(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":    1,
+		"login": server.currentLogin,
+	})
+}
+
+func (server *Server) searchUsers(query string) []User {
+	query = strings.ToLower(query)
+
+	users := []User{}
+	for _, user := range server.users {
+		if strings.Contains(strings.ToLower(user.Login), query) || strings.Contains(strings.ToLower(user.Email), query) {
+			users = append(users, *user)
+		}
+	}
+
+	return users
+}
+
+func (server *Server) listCurrentOrgUsers(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	writeJSON(w, http.StatusOK, server.searchUsers(r.URL.Query().Get("query")))
+}
+
+func (server *Server) lookupUsers(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	// like Grafana, lookups do not expose the email of users
+	users := []map[string]interface{}{}
+	for _, user := range server.searchUsers(r.URL.Query().Get("query")) {
+		users = append(users, map[string]interface{}{
+			"userId": user.ID,
+			"login":  user.Login,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, users)
+}
@@ -0,0 +1,117 @@
+package grabanatest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type dashboardVersion struct {
+	ID            int             `json:"id"`
+	DashboardID   int             `json:"dashboardId"`
+	ParentVersion int             `json:"parentVersion"`
+	RestoredFrom  int             `json:"restoredFrom"`
+	Version       int             `json:"version"`
+	Created       time.Time       `json:"created"`
+	CreatedBy     string          `json:"createdBy"`
+	Message       string          `json:"message"`
+	Data          json.RawMessage `json:"data,omitempty"`
+}
+
+func (server *Server) registerVersionRoutes() {
+	server.handle(http.MethodGet, "/api/dashboards/uid/{uid}/versions", server.listDashboardVersions)
+	server.handle(http.MethodGet, "/api/dashboards/uid/{uid}/versions/{version}", server.getDashboardVersion)
+	server.handle(http.MethodPost, "/api/dashboards/uid/{uid}/restore", server.restoreDashboardVersion)
+}
+
+func (dashboard *Dashboard) version(version int) *dashboardVersion {
+	for i := range dashboard.versions {
+		if dashboard.versions[i].Version == version {
+			return &dashboard.versions[i]
+		}
+	}
+
+	return nil
+}
+
+func (server *Server) listDashboardVersions(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	dashboard := server.dashboardByUID(params["uid"])
+	if dashboard == nil {
+		writeError(w, http.StatusNotFound, "Dashboard not found")
+		return
+	}
+
+	versions := make([]dashboardVersion, 0, len(dashboard.versions))
+	for i := len(dashboard.versions) - 1; i >= 0; i-- {
+		version := dashboard.versions[i]
+		version.Data = nil
+
+		versions = append(versions, version)
+	}
+
+	writeJSON(w, http.StatusOK, versions)
+}
+
+func (server *Server) getDashboardVersion(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	dashboard := server.dashboardByUID(params["uid"])
+	if dashboard == nil {
+		writeError(w, http.StatusNotFound, "Dashboard not found")
+		return
+	}
+
+	versionNumber, err := strconv.Atoi(params["version"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "version is invalid")
+		return
+	}
+
+	version := dashboard.version(versionNumber)
+	if version == nil {
+		writeError(w, http.StatusNotFound, "Dashboard version not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, version)
+}
+
+func (server *Server) restoreDashboardVersion(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	dashboard := server.dashboardByUID(params["uid"])
+	if dashboard == nil {
+		writeError(w, http.StatusNotFound, "Dashboard not found")
+		return
+	}
+
+	request := struct {
+		Version int `json:"version"`
+	}{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	version := dashboard.version(request.Version)
+	if version == nil {
+		writeError(w, http.StatusNotFound, "Dashboard version not found")
+		return
+	}
+
+	model := map[string]interface{}{}
+	if err := json.Unmarshal(version.Data, &model); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	message := "Restored from version " + strconv.Itoa(request.Version)
+	if err := server.saveDashboardVersion(dashboard, model, message, request.Version); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":      dashboard.ID,
+		"uid":     dashboard.UID,
+		"url":     "/d/" + dashboard.UID,
+		"status":  "success",
+		"version": dashboard.Version,
+	})
+}
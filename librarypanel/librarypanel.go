@@ -0,0 +1,87 @@
+package librarypanel
+
+import (
+	"fmt"
+
+	"github.com/K-Phoen/grabana/errors"
+	"github.com/K-Phoen/sdk"
+)
+
+// Option represents an option that can be used to configure a reference to
+// a library panel.
+type Option func(panel *LibraryPanel) error
+
+// LibraryPanel represents a reference to a library panel. The actual
+// content of the panel is stored in Grafana and shared between dashboards.
+// See https://grafana.com/docs/grafana/latest/dashboards/build-dashboards/manage-library-panels/
+type LibraryPanel struct {
+	Builder *sdk.Panel
+}
+
+// New creates a new reference to the library panel identified by uid.
+func New(uid string, options ...Option) (*LibraryPanel, error) {
+	if uid == "" {
+		return nil, fmt.Errorf("library panel uid can not be empty: %w", errors.ErrInvalidArgument)
+	}
+
+	panel := &LibraryPanel{Builder: sdk.NewCustom("")}
+	panel.Builder.IsNew = false
+	panel.Builder.Title = ""
+	panel.Builder.Type = ""
+	panel.Builder.Renderer = nil
+	panel.Builder.Span = 6
+	*panel.Builder.CustomPanel = map[string]interface{}{
+		"libraryPanel": map[string]interface{}{"uid": uid},
+	}
+
+	for _, opt := range options {
+		if err := opt(panel); err != nil {
+			return nil, err
+		}
+	}
+
+	return panel, nil
+}
+
+// UID returns the UID of the referenced library panel.
+func (panel *LibraryPanel) UID() string {
+	return panel.ref()["uid"].(string)
+}
+
+func (panel *LibraryPanel) ref() map[string]interface{} {
+	return (*panel.Builder.CustomPanel)["libraryPanel"].(map[string]interface{})
+}
+
+// Name sets the name of the referenced library panel. It is purely
+// informative: Grafana always displays the panel as stored in the library.
+func Name(name string) Option {
+	return func(panel *LibraryPanel) error {
+		panel.ref()["name"] = name
+		panel.Builder.Title = name
+
+		return nil
+	}
+}
+
+// Span sets the width of the panel, in grid units. Should be a positive
+// number between 1 and 12. Example: 6.
+func Span(span float32) Option {
+	return func(panel *LibraryPanel) error {
+		if span < 1 || span > 12 {
+			return fmt.Errorf("span must be between 1 and 12: %w", errors.ErrInvalidArgument)
+		}
+
+		panel.Builder.Span = span
+
+		return nil
+	}
+}
+
+// Height sets the height of the panel, in pixels. Example: "400px".
+func Height(height string) Option {
+	return func(panel *LibraryPanel) error {
+		panel.Builder.Height = &height
+
+		return nil
+	}
+}
@@ -0,0 +1,70 @@
+package librarypanel
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/K-Phoen/grabana/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLibraryPanelReferencesCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("slo-burn-rate")
+
+	req.NoError(err)
+	req.False(panel.Builder.IsNew)
+	req.Equal("slo-burn-rate", panel.UID())
+	req.Equal(float32(6), panel.Builder.Span)
+}
+
+func TestLibraryPanelReferencesRequireAUID(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("")
+
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestLibraryPanelReferencesAreSerializedWithTheirUID(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("slo-burn-rate", Name("SLO burn rate"))
+	req.NoError(err)
+
+	buf, err := json.Marshal(panel.Builder)
+	req.NoError(err)
+
+	model := map[string]interface{}{}
+	req.NoError(json.Unmarshal(buf, &model))
+
+	req.Equal(map[string]interface{}{"uid": "slo-burn-rate", "name": "SLO burn rate"}, model["libraryPanel"])
+	req.Equal("SLO burn rate", model["title"])
+}
+
+func TestSpanCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("uid", Span(12))
+
+	req.NoError(err)
+	req.Equal(float32(12), panel.Builder.Span)
+}
+
+func TestInvalidSpanIsRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("uid", Span(13))
+
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestHeightCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("uid", Height("400px"))
+
+	req.NoError(err)
+	req.Equal("400px", *(panel.Builder.Height).(*string))
+}
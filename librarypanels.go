@@ -0,0 +1,190 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	grabanaErrors "github.com/K-Phoen/grabana/errors"
+	"github.com/K-Phoen/sdk"
+)
+
+// ErrLibraryPanelNotFound is returned when the given library panel can not be found.
+var ErrLibraryPanelNotFound = errors.New("library panel not found")
+
+// libraryPanelKind identifies panels among library elements.
+const libraryPanelKind = 1
+
+// LibraryPanel represents a panel stored in the library, that can be shared
+// between dashboards.
+// See https://grafana.com/docs/grafana/latest/dashboards/build-dashboards/manage-library-panels/
+type LibraryPanel struct {
+	ID          uint   `json:"id"`
+	UID         string `json:"uid"`
+	Name        string `json:"name"`
+	FolderUID   string `json:"folderUid"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Version     int    `json:"version"`
+	// Model is the JSON model of the panel.
+	Model json.RawMessage `json:"model"`
+}
+
+// Panel decodes the model of the library panel.
+func (panel LibraryPanel) Panel() (*sdk.Panel, error) {
+	model := &sdk.Panel{}
+	if err := json.Unmarshal(panel.Model, model); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// CreateLibraryPanel stores the given panel in the library, in the given
+// folder. The panel can be built with any of grabana's panel builders, e.g.
+// the Builder field of a timeseries.TimeSeries. Its title is used as name.
+// An empty uid lets Grafana generate one.
+func (client *Client) CreateLibraryPanel(ctx context.Context, folder *Folder, uid string, panel *sdk.Panel) (*LibraryPanel, error) {
+	buf, err := json.Marshal(struct {
+		UID       string     `json:"uid,omitempty"`
+		FolderUID string     `json:"folderUid"`
+		Name      string     `json:"name"`
+		Kind      int        `json:"kind"`
+		Model     *sdk.Panel `json:"model"`
+	}{
+		UID:       uid,
+		FolderUID: folder.UID,
+		Name:      panel.Title,
+		Kind:      libraryPanelKind,
+		Model:     panel,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPost, "/api/library-elements", buf)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	return decodeLibraryPanel(resp)
+}
+
+// GetLibraryPanelByUID finds a library panel, given its UID.
+func (client *Client) GetLibraryPanelByUID(ctx context.Context, uid string) (*LibraryPanel, error) {
+	resp, err := client.get(ctx, "/api/library-elements/"+url.PathEscape(uid))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrLibraryPanelNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	return decodeLibraryPanel(resp)
+}
+
+// UpdateLibraryPanel replaces the model of a library panel. Every dashboard
+// referencing it is affected.
+func (client *Client) UpdateLibraryPanel(ctx context.Context, uid string, panel *sdk.Panel) (*LibraryPanel, error) {
+	existing, err := client.GetLibraryPanelByUID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := json.Marshal(struct {
+		FolderUID string     `json:"folderUid"`
+		Name      string     `json:"name"`
+		Kind      int        `json:"kind"`
+		Model     *sdk.Panel `json:"model"`
+		Version   int        `json:"version"`
+	}{
+		FolderUID: existing.FolderUID,
+		Name:      panel.Title,
+		Kind:      libraryPanelKind,
+		Model:     panel,
+		Version:   existing.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPatch, "/api/library-elements/"+url.PathEscape(uid), buf)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrLibraryPanelNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	return decodeLibraryPanel(resp)
+}
+
+// UpsertLibraryPanel creates or updates the library panel identified by uid.
+// The uid is required.
+func (client *Client) UpsertLibraryPanel(ctx context.Context, folder *Folder, uid string, panel *sdk.Panel) (*LibraryPanel, error) {
+	if uid == "" {
+		return nil, fmt.Errorf("library panel uid can not be empty: %w", grabanaErrors.ErrInvalidArgument)
+	}
+
+	_, err := client.GetLibraryPanelByUID(ctx, uid)
+	if errors.Is(err, ErrLibraryPanelNotFound) {
+		return client.CreateLibraryPanel(ctx, folder, uid, panel)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return client.UpdateLibraryPanel(ctx, uid, panel)
+}
+
+// DeleteLibraryPanel deletes a library panel given its UID. Library panels
+// still used by dashboards can not be deleted.
+func (client *Client) DeleteLibraryPanel(ctx context.Context, uid string) error {
+	resp, err := client.delete(ctx, "/api/library-elements/"+url.PathEscape(uid))
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrLibraryPanelNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
+
+func decodeLibraryPanel(resp *http.Response) (*LibraryPanel, error) {
+	response := struct {
+		Result LibraryPanel `json:"result"`
+	}{}
+	if err := decodeJSON(resp.Body, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Result, nil
+}
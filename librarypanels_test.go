@@ -0,0 +1,121 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	grabanaErrors "github.com/K-Phoen/grabana/errors"
+	"github.com/K-Phoen/grabana/timeseries"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLibraryPanelsCanBeCreatedFromPanelBuilders(t *testing.T) {
+	req := require.New(t)
+
+	var payload map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodPost, r.Method)
+		req.Equal("/api/library-elements", r.URL.Path)
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+
+		_, _ = fmt.Fprintln(w, `{"result": {"id": 1, "uid": "slo-burn-rate", "name": "SLO burn rate", "folderUid": "folder-uid", "type": "timeseries", "version": 1, "model": {"type": "timeseries", "title": "SLO burn rate"}}}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+	panel, err := timeseries.New("SLO burn rate")
+	req.NoError(err)
+
+	libraryPanel, err := client.CreateLibraryPanel(context.TODO(), &Folder{UID: "folder-uid"}, "slo-burn-rate", panel.Builder)
+
+	req.NoError(err)
+	req.Equal("slo-burn-rate", payload["uid"])
+	req.Equal("folder-uid", payload["folderUid"])
+	req.Equal("SLO burn rate", payload["name"])
+	req.Equal(float64(1), payload["kind"])
+	req.Equal("timeseries", payload["model"].(map[string]interface{})["type"])
+
+	req.Equal("slo-burn-rate", libraryPanel.UID)
+	req.Equal(1, libraryPanel.Version)
+
+	model, err := libraryPanel.Panel()
+	req.NoError(err)
+	req.Equal("SLO burn rate", model.Title)
+}
+
+func TestFetchingAnUnknownLibraryPanelFailsCleanly(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/library-elements/unknown", r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.GetLibraryPanelByUID(context.TODO(), "unknown")
+
+	req.ErrorIs(err, ErrLibraryPanelNotFound)
+	req.True(IsNotFound(err))
+}
+
+func TestLibraryPanelsAreUpdatedWithTheirLatestVersion(t *testing.T) {
+	req := require.New(t)
+
+	var payload map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/library-elements/slo-burn-rate", r.URL.Path)
+
+		if r.Method == http.MethodPatch {
+			req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+		}
+
+		_, _ = fmt.Fprintln(w, `{"result": {"id": 1, "uid": "slo-burn-rate", "name": "SLO burn rate", "folderUid": "folder-uid", "version": 3, "model": {}}}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+	panel, err := timeseries.New("SLO burn rate (v2)")
+	req.NoError(err)
+
+	_, err = client.UpdateLibraryPanel(context.TODO(), "slo-burn-rate", panel.Builder)
+
+	req.NoError(err)
+	req.Equal(float64(3), payload["version"])
+	req.Equal("folder-uid", payload["folderUid"])
+	req.Equal("SLO burn rate (v2)", payload["name"])
+}
+
+func TestUpsertingALibraryPanelRequiresAUID(t *testing.T) {
+	req := require.New(t)
+
+	client := NewClient(http.DefaultClient, "http://localhost")
+	panel, err := timeseries.New("Errors")
+	req.NoError(err)
+
+	_, err = client.UpsertLibraryPanel(context.TODO(), &Folder{}, "", panel.Builder)
+
+	req.ErrorIs(err, grabanaErrors.ErrInvalidArgument)
+}
+
+func TestDeletingAConnectedLibraryPanelFails(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodDelete, r.Method)
+
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = fmt.Fprintln(w, `{"message": "the library element has connections"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.DeleteLibraryPanel(context.TODO(), "slo-burn-rate")
+
+	req.Error(err)
+	req.True(IsForbidden(err))
+}
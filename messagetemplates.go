@@ -0,0 +1,109 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// ErrMessageTemplateNotFound is returned when the given message template can not be found.
+var ErrMessageTemplateNotFound = errors.New("message template not found")
+
+// MessageTemplate represents a notification template, that contact points
+// can use to format their messages.
+// See https://grafana.com/docs/grafana/latest/alerting/manage-notifications/template-notifications/
+type MessageTemplate struct {
+	Name     string `json:"name"`
+	Template string `json:"template"`
+}
+
+// ListMessageTemplates returns the message templates of the current organization.
+func (client *Client) ListMessageTemplates(ctx context.Context) ([]MessageTemplate, error) {
+	resp, err := client.get(ctx, "/api/v1/provisioning/templates")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var templates []MessageTemplate
+	if err := decodeJSON(resp.Body, &templates); err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// GetMessageTemplate finds a message template, given its name.
+func (client *Client) GetMessageTemplate(ctx context.Context, name string) (*MessageTemplate, error) {
+	resp, err := client.get(ctx, "/api/v1/provisioning/templates/"+url.PathEscape(name))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrMessageTemplateNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	template := &MessageTemplate{}
+	if err := decodeJSON(resp.Body, template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// UpsertMessageTemplate creates or replaces a message template.
+func (client *Client) UpsertMessageTemplate(ctx context.Context, name string, template string) error {
+	buf, err := json.Marshal(struct {
+		Template string `json:"template"`
+	}{
+		Template: template,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPut, "/api/v1/provisioning/templates/"+url.PathEscape(name), buf)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
+
+// DeleteMessageTemplate deletes a message template, given its name.
+func (client *Client) DeleteMessageTemplate(ctx context.Context, name string) error {
+	resp, err := client.delete(ctx, "/api/v1/provisioning/templates/"+url.PathEscape(name))
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrMessageTemplateNotFound
+	}
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
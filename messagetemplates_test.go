@@ -0,0 +1,78 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertMessageTemplate(t *testing.T) {
+	req := require.New(t)
+
+	payload := map[string]interface{}{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodPut, r.Method)
+		req.Equal("/api/v1/provisioning/templates/team-a", r.URL.Path)
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = fmt.Fprintln(w, `{}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.UpsertMessageTemplate(context.TODO(), "team-a", `{{ define "team-a" }}alert!{{ end }}`)
+
+	req.NoError(err)
+	req.Equal(`{{ define "team-a" }}alert!{{ end }}`, payload["template"])
+}
+
+func TestListMessageTemplates(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintln(w, `[{"name": "team-a", "template": "content"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	templates, err := client.ListMessageTemplates(context.TODO())
+
+	req.NoError(err)
+	req.Equal([]MessageTemplate{{Name: "team-a", Template: "content"}}, templates)
+}
+
+func TestGetUnknownMessageTemplateFails(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.GetMessageTemplate(context.TODO(), "unknown")
+
+	req.ErrorIs(err, ErrMessageTemplateNotFound)
+}
+
+func TestDeleteMessageTemplate(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodDelete, r.Method)
+		req.Equal("/api/v1/provisioning/templates/team-a", r.URL.Path)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	req.NoError(client.DeleteMessageTemplate(context.TODO(), "team-a"))
+}
@@ -0,0 +1,137 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/K-Phoen/grabana/alertmanager"
+)
+
+// ErrMuteTimingNotFound is returned when the given mute timing can not be found.
+var ErrMuteTimingNotFound = errors.New("mute timing not found")
+
+// MuteTiming represents a named set of periods during which notification
+// policies referring to it are muted (or active).
+// See https://grafana.com/docs/grafana/latest/alerting/manage-notifications/mute-timings/
+type MuteTiming struct {
+	Name          string             `json:"name"`
+	TimeIntervals []MuteTimingPeriod `json:"time_intervals"`
+}
+
+// MuteTimingPeriod describes a period of a mute timing. Its fields are
+// combined using a logical "AND".
+type MuteTimingPeriod struct {
+	Times       []MuteTimingTimeRange `json:"times,omitempty"`
+	Weekdays    []string              `json:"weekdays,omitempty"`
+	DaysOfMonth []string              `json:"days_of_month,omitempty"`
+	Months      []string              `json:"months,omitempty"`
+	Years       []string              `json:"years,omitempty"`
+	Location    string                `json:"location,omitempty"`
+}
+
+// MuteTimingTimeRange is a time range, in the "HH:MM" format.
+type MuteTimingTimeRange struct {
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+// ListMuteTimings returns the mute timings of the current organization.
+func (client *Client) ListMuteTimings(ctx context.Context) ([]MuteTiming, error) {
+	resp, err := client.get(ctx, "/api/v1/provisioning/mute-timings")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var timings []MuteTiming
+	if err := decodeJSON(resp.Body, &timings); err != nil {
+		return nil, err
+	}
+
+	return timings, nil
+}
+
+// GetMuteTiming finds a mute timing, given its name.
+func (client *Client) GetMuteTiming(ctx context.Context, name string) (*MuteTiming, error) {
+	resp, err := client.get(ctx, "/api/v1/provisioning/mute-timings/"+url.PathEscape(name))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrMuteTimingNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	timing := &MuteTiming{}
+	if err := decodeJSON(resp.Body, timing); err != nil {
+		return nil, err
+	}
+
+	return timing, nil
+}
+
+// UpsertMuteTiming creates or replaces a mute timing, identified by its name.
+func (client *Client) UpsertMuteTiming(ctx context.Context, timing alertmanager.TimeInterval) error {
+	buf, err := json.Marshal(timing)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.GetMuteTiming(ctx, timing.Name())
+	if err != nil && !errors.Is(err, ErrMuteTimingNotFound) {
+		return err
+	}
+
+	method := http.MethodPost
+	path := "/api/v1/provisioning/mute-timings"
+	if err == nil {
+		method = http.MethodPut
+		path += "/" + url.PathEscape(timing.Name())
+	}
+
+	resp, err := client.sendJSON(ctx, method, path, buf)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
+
+// DeleteMuteTiming deletes a mute timing, given its name. Mute timings
+// still used by notification policies can not be deleted.
+func (client *Client) DeleteMuteTiming(ctx context.Context, name string) error {
+	resp, err := client.delete(ctx, "/api/v1/provisioning/mute-timings/"+url.PathEscape(name))
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrMuteTimingNotFound
+	}
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/K-Phoen/grabana/alertmanager"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertingAnUnknownMuteTimingCreatesIt(t *testing.T) {
+	req := require.New(t)
+
+	payload := map[string]interface{}{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet:
+			req.Equal("/api/v1/provisioning/mute-timings/off-hours", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPost:
+			req.Equal("/api/v1/provisioning/mute-timings", r.URL.Path)
+			req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintln(w, `{}`)
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+	timing := alertmanager.MuteTiming("off-hours", alertmanager.Period(alertmanager.Weekdays("saturday", "sunday")))
+
+	err := client.UpsertMuteTiming(context.TODO(), timing)
+
+	req.NoError(err)
+	req.Equal("off-hours", payload["name"])
+	req.Len(payload["time_intervals"], 1)
+}
+
+func TestUpsertingAKnownMuteTimingReplacesIt(t *testing.T) {
+	req := require.New(t)
+
+	updated := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet:
+			_, _ = fmt.Fprintln(w, `{"name": "off-hours", "time_intervals": []}`)
+		case r.Method == http.MethodPut:
+			req.Equal("/api/v1/provisioning/mute-timings/off-hours", r.URL.Path)
+			updated = true
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = fmt.Fprintln(w, `{}`)
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.UpsertMuteTiming(context.TODO(), alertmanager.MuteTiming("off-hours"))
+
+	req.NoError(err)
+	req.True(updated)
+}
+
+func TestListMuteTimings(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/v1/provisioning/mute-timings", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `[{"name": "off-hours", "time_intervals": [{"times": [{"start_time": "18:00", "end_time": "24:00"}], "location": "Europe/Paris"}]}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	timings, err := client.ListMuteTimings(context.TODO())
+
+	req.NoError(err)
+	req.Equal([]MuteTiming{
+		{
+			Name: "off-hours",
+			TimeIntervals: []MuteTimingPeriod{
+				{
+					Times:    []MuteTimingTimeRange{{StartTime: "18:00", EndTime: "24:00"}},
+					Location: "Europe/Paris",
+				},
+			},
+		},
+	}, timings)
+}
+
+func TestDeletingAnUnknownMuteTimingFails(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodDelete, r.Method)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.DeleteMuteTiming(context.TODO(), "unknown")
+
+	req.ErrorIs(err, ErrMuteTimingNotFound)
+}
@@ -0,0 +1,98 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/K-Phoen/grabana/alertmanager"
+)
+
+// NotificationPolicy represents a node of the notification policy tree, as
+// exposed by Grafana's provisioning API. The root of the tree defines the
+// default contact point and settings.
+// See https://grafana.com/docs/grafana/latest/developers/http_api/alerting_provisioning/#notification-policies
+type NotificationPolicy struct {
+	Receiver            string               `json:"receiver,omitempty"`
+	GroupBy             []string             `json:"group_by,omitempty"`
+	ObjectMatchers      [][3]string          `json:"object_matchers,omitempty"`
+	Continue            bool                 `json:"continue,omitempty"`
+	GroupWait           string               `json:"group_wait,omitempty"`
+	GroupInterval       string               `json:"group_interval,omitempty"`
+	RepeatInterval      string               `json:"repeat_interval,omitempty"`
+	MuteTimeIntervals   []string             `json:"mute_time_intervals,omitempty"`
+	ActiveTimeIntervals []string             `json:"active_time_intervals,omitempty"`
+	Routes              []NotificationPolicy `json:"routes,omitempty"`
+}
+
+// GetNotificationPolicies returns the root of the notification policy tree.
+func (client *Client) GetNotificationPolicies(ctx context.Context) (*NotificationPolicy, error) {
+	resp, err := client.get(ctx, "/api/v1/provisioning/policies")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	policy := &NotificationPolicy{}
+	if err := decodeJSON(resp.Body, policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// SetNotificationPolicies replaces the notification policy tree with the
+// routing configuration of the given manager. Contact points, templates and
+// mute timings defined on the manager are ignored: they can be provisioned
+// separately with UpsertContactPoint, UpsertMessageTemplate and
+// UpsertMuteTiming.
+func (client *Client) SetNotificationPolicies(ctx context.Context, manager *alertmanager.Manager) error {
+	buf, err := manager.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	config := struct {
+		Config struct {
+			Route json.RawMessage `json:"route"`
+		} `json:"alertmanager_config"`
+	}{}
+	if err := json.Unmarshal(buf, &config); err != nil {
+		return err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPut, "/api/v1/provisioning/policies", config.Config.Route)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
+
+// ResetNotificationPolicies resets the notification policy tree to Grafana's
+// default one.
+func (client *Client) ResetNotificationPolicies(ctx context.Context) error {
+	resp, err := client.delete(ctx, "/api/v1/provisioning/policies")
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/K-Phoen/grabana/alertmanager"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNotificationPolicies(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/v1/provisioning/policies", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `{
+  "receiver": "default",
+  "group_by": ["alertname"],
+  "routes": [
+    {"receiver": "team-a", "object_matchers": [["team", "=", "a"]], "routes": [{"receiver": "team-a-critical", "continue": true}]}
+  ]
+}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	root, err := client.GetNotificationPolicies(context.TODO())
+
+	req.NoError(err)
+	req.Equal("default", root.Receiver)
+	req.Len(root.Routes, 1)
+	req.Equal([][3]string{{"team", "=", "a"}}, root.Routes[0].ObjectMatchers)
+	req.True(root.Routes[0].Routes[0].Continue)
+}
+
+func TestSetNotificationPoliciesOnlySendsTheRoutingTree(t *testing.T) {
+	req := require.New(t)
+
+	payload := map[string]interface{}{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodPut, r.Method)
+		req.Equal("/api/v1/provisioning/policies", r.URL.Path)
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = fmt.Fprintln(w, `{"message": "policies updated"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+	manager := alertmanager.New(
+		alertmanager.DefaultContactPoint("default"),
+		alertmanager.Routing(alertmanager.Policy("team-a", alertmanager.TagEq("team", "a"))),
+	)
+
+	err := client.SetNotificationPolicies(context.TODO(), manager)
+
+	req.NoError(err)
+	req.Equal("default", payload["receiver"])
+	req.Len(payload["routes"], 1)
+	req.NotContains(payload, "receivers")
+}
+
+func TestResetNotificationPolicies(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodDelete, r.Method)
+		req.Equal("/api/v1/provisioning/policies", r.URL.Path)
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	req.NoError(client.ResetNotificationPolicies(context.TODO()))
+}
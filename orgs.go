@@ -0,0 +1,266 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ErrOrgNotFound is returned when the given organization can not be found.
+var ErrOrgNotFound = errors.New("organization not found")
+
+// OrgRole represents the role of a user within an organization.
+type OrgRole string
+
+const (
+	OrgRoleAdmin  OrgRole = "Admin"
+	OrgRoleEditor OrgRole = "Editor"
+	OrgRoleViewer OrgRole = "Viewer"
+	OrgRoleNone   OrgRole = "None"
+)
+
+// Org represents a Grafana organization.
+// See https://grafana.com/docs/grafana/latest/administration/organization-management/
+type Org struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// OrgUser represents a user, as a member of an organization.
+type OrgUser struct {
+	OrgID  uint    `json:"orgId"`
+	UserID uint    `json:"userId"`
+	Login  string  `json:"login"`
+	Email  string  `json:"email"`
+	Name   string  `json:"name"`
+	Role   OrgRole `json:"role"`
+}
+
+// orgsPerPage is the number of organizations fetched per page.
+const orgsPerPage = 1000
+
+// ListOrgs returns all organizations. Requires server admin permissions.
+// Organizations are fetched page by page until every one of them is
+// retrieved.
+func (client *Client) ListOrgs(ctx context.Context) ([]Org, error) {
+	orgs := []Org{}
+
+	for page := 1; ; page++ {
+		results, err := client.listOrgsPage(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+
+		orgs = append(orgs, results...)
+
+		if len(results) < orgsPerPage {
+			return orgs, nil
+		}
+	}
+}
+
+func (client *Client) listOrgsPage(ctx context.Context, page int) ([]Org, error) {
+	resp, err := client.get(ctx, fmt.Sprintf("/api/orgs?perpage=%d&page=%d", orgsPerPage, page))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var orgs []Org
+	if err := decodeJSON(resp.Body, &orgs); err != nil {
+		return nil, err
+	}
+
+	return orgs, nil
+}
+
+// GetOrgByName finds an organization, given its name.
+func (client *Client) GetOrgByName(ctx context.Context, name string) (*Org, error) {
+	resp, err := client.get(ctx, "/api/orgs/name/"+url.PathEscape(name))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrOrgNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var org Org
+	if err := decodeJSON(resp.Body, &org); err != nil {
+		return nil, err
+	}
+
+	return &org, nil
+}
+
+// CreateOrg creates an organization.
+func (client *Client) CreateOrg(ctx context.Context, name string) (*Org, error) {
+	buf, err := json.Marshal(struct {
+		Name string `json:"name"`
+	}{
+		Name: name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPost, "/api/orgs", buf)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var response struct {
+		OrgID uint `json:"orgId"`
+	}
+	if err := decodeJSON(resp.Body, &response); err != nil {
+		return nil, err
+	}
+
+	return &Org{ID: response.OrgID, Name: name}, nil
+}
+
+// FindOrCreateOrg returns the organization by its name or creates it if it doesn't exist.
+func (client *Client) FindOrCreateOrg(ctx context.Context, name string) (*Org, error) {
+	org, err := client.GetOrgByName(ctx, name)
+	if err != nil && err != ErrOrgNotFound {
+		return nil, fmt.Errorf("could not find or create organization: %w", err)
+	}
+	if org == nil {
+		org, err = client.CreateOrg(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("could not create organization: %w", err)
+		}
+	}
+
+	return org, nil
+}
+
+// DeleteOrg deletes an organization, along with everything it contains.
+func (client *Client) DeleteOrg(ctx context.Context, orgID uint) error {
+	resp, err := client.delete(ctx, fmt.Sprintf("/api/orgs/%d", orgID))
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrOrgNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
+
+// ListOrgUsers returns the members of an organization.
+func (client *Client) ListOrgUsers(ctx context.Context, orgID uint) ([]OrgUser, error) {
+	resp, err := client.get(ctx, fmt.Sprintf("/api/orgs/%d/users", orgID))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrOrgNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var users []OrgUser
+	if err := decodeJSON(resp.Body, &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// AddOrgUser adds an existing user to an organization, given their login or email.
+func (client *Client) AddOrgUser(ctx context.Context, orgID uint, loginOrEmail string, role OrgRole) error {
+	buf, err := json.Marshal(struct {
+		LoginOrEmail string  `json:"loginOrEmail"`
+		Role         OrgRole `json:"role"`
+	}{
+		LoginOrEmail: loginOrEmail,
+		Role:         role,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPost, fmt.Sprintf("/api/orgs/%d/users", orgID), buf)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
+
+// UpdateOrgUserRole changes the role of a member of an organization.
+func (client *Client) UpdateOrgUserRole(ctx context.Context, orgID uint, userID uint, role OrgRole) error {
+	buf, err := json.Marshal(struct {
+		Role OrgRole `json:"role"`
+	}{
+		Role: role,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPatch, fmt.Sprintf("/api/orgs/%d/users/%d", orgID, userID), buf)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
+
+// RemoveOrgUser removes a member from an organization.
+func (client *Client) RemoveOrgUser(ctx context.Context, orgID uint, userID uint) error {
+	resp, err := client.delete(ctx, fmt.Sprintf("/api/orgs/%d/users/%d", orgID, userID))
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
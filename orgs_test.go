@@ -0,0 +1,166 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrgIDHeaderCanBeSet(t *testing.T) {
+	req := require.New(t)
+
+	var orgHeaders []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		orgHeaders = append(orgHeaders, r.Header.Get("X-Grafana-Org-Id"))
+		_, _ = fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL, WithOrgID(2))
+
+	_, err := client.ListFolders(context.TODO())
+	req.NoError(err)
+
+	_, err = client.WithOrg(3).ListFolders(context.TODO())
+	req.NoError(err)
+
+	// the original client is left untouched
+	_, err = client.ListFolders(context.TODO())
+	req.NoError(err)
+
+	_, err = NewClient(http.DefaultClient, ts.URL).ListFolders(context.TODO())
+	req.NoError(err)
+
+	req.Equal([]string{"2", "3", "2", ""}, orgHeaders)
+}
+
+func TestOrgsCanBeListed(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintln(w, `[{"id": 1, "name": "Main Org."}, {"id": 2, "name": "Team"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	orgs, err := client.ListOrgs(context.TODO())
+
+	req.NoError(err)
+	req.Equal([]Org{{ID: 1, Name: "Main Org."}, {ID: 2, Name: "Team"}}, orgs)
+}
+
+func TestOrgsAreListedPageByPage(t *testing.T) {
+	req := require.New(t)
+
+	var requestedPages []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		requestedPages = append(requestedPages, page)
+		req.Equal("1000", r.URL.Query().Get("perpage"))
+
+		count := 1000
+		if page == "2" {
+			count = 3
+		}
+
+		orgs := make([]Org, 0, count)
+		for i := 0; i < count; i++ {
+			orgs = append(orgs, Org{ID: uint(len(requestedPages)*1000 + i), Name: fmt.Sprintf("org-%s-%d", page, i)})
+		}
+
+		_ = json.NewEncoder(w).Encode(orgs)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	orgs, err := client.ListOrgs(context.TODO())
+
+	req.NoError(err)
+	req.Len(orgs, 1003)
+	req.Equal([]string{"1", "2"}, requestedPages)
+}
+
+func TestAnExplicitErrorIsReturnedIfTheOrgIsNotFound(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprintln(w, `{"message": "Organization not found"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	org, err := client.GetOrgByName(context.TODO(), "unknown")
+
+	req.Nil(org)
+	req.Equal(ErrOrgNotFound, err)
+	req.True(IsNotFound(err))
+}
+
+func TestFindOrCreateOrgCreatesMissingOrgs(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			req.Equal("/api/orgs/name/New Team", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		payload := map[string]string{}
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+		req.Equal("New Team", payload["name"])
+
+		_, _ = fmt.Fprintln(w, `{"orgId": 4, "message": "Organization created"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	org, err := client.FindOrCreateOrg(context.TODO(), "New Team")
+
+	req.NoError(err)
+	req.Equal(&Org{ID: 4, Name: "New Team"}, org)
+}
+
+func TestOrgUsersCanBeManaged(t *testing.T) {
+	req := require.New(t)
+
+	var calls []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+
+		if r.Method == http.MethodGet {
+			_, _ = fmt.Fprintln(w, `[{"orgId": 2, "userId": 7, "login": "joe", "email": "joe@example.com", "role": "Viewer"}]`)
+			return
+		}
+
+		_, _ = fmt.Fprintln(w, `{"message": "ok"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	users, err := client.ListOrgUsers(context.TODO(), 2)
+	req.NoError(err)
+	req.Len(users, 1)
+	req.Equal(OrgRoleViewer, users[0].Role)
+
+	req.NoError(client.AddOrgUser(context.TODO(), 2, "jane@example.com", OrgRoleEditor))
+	req.NoError(client.UpdateOrgUserRole(context.TODO(), 2, 7, OrgRoleAdmin))
+	req.NoError(client.RemoveOrgUser(context.TODO(), 2, 7))
+	req.NoError(client.DeleteOrg(context.TODO(), 2))
+
+	req.Equal([]string{
+		"GET /api/orgs/2/users",
+		"POST /api/orgs/2/users",
+		"PATCH /api/orgs/2/users/7",
+		"DELETE /api/orgs/2/users/7",
+		"DELETE /api/orgs/2",
+	}, calls)
+}
@@ -0,0 +1,129 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/K-Phoen/grabana/dashboard"
+)
+
+// Permission grants a level of access on a folder or a dashboard to either a
+// user, a team or a built-in role.
+// See https://grafana.com/docs/grafana/latest/administration/roles-and-permissions/#dashboard-permissions
+type Permission struct {
+	UserID     uint                      `json:"userId,omitempty"`
+	TeamID     uint                      `json:"teamId,omitempty"`
+	Role       OrgRole                   `json:"role,omitempty"`
+	Permission dashboard.PermissionLevel `json:"permission"`
+	// Inherited is true for dashboard permissions coming from the folder.
+	// Those are ignored when replacing permissions.
+	Inherited bool `json:"inherited,omitempty"`
+}
+
+// GetFolderPermissions lists the permissions set on a folder.
+func (client *Client) GetFolderPermissions(ctx context.Context, folderUID string) ([]Permission, error) {
+	return client.getPermissions(ctx, "/api/folders/"+url.PathEscape(folderUID)+"/permissions", ErrFolderNotFound)
+}
+
+// SetFolderPermissions replaces the permissions set on a folder.
+func (client *Client) SetFolderPermissions(ctx context.Context, folderUID string, permissions []Permission) error {
+	return client.setPermissions(ctx, "/api/folders/"+url.PathEscape(folderUID)+"/permissions", permissions, ErrFolderNotFound)
+}
+
+// GetDashboardPermissions lists the permissions set on a dashboard, including
+// the ones inherited from its folder.
+func (client *Client) GetDashboardPermissions(ctx context.Context, dashboardUID string) ([]Permission, error) {
+	return client.getPermissions(ctx, "/api/dashboards/uid/"+url.PathEscape(dashboardUID)+"/permissions", ErrDashboardNotFound)
+}
+
+// SetDashboardPermissions replaces the permissions set on a dashboard.
+// Permissions inherited from the folder are not affected.
+func (client *Client) SetDashboardPermissions(ctx context.Context, dashboardUID string, permissions []Permission) error {
+	return client.setPermissions(ctx, "/api/dashboards/uid/"+url.PathEscape(dashboardUID)+"/permissions", permissions, ErrDashboardNotFound)
+}
+
+func (client *Client) getPermissions(ctx context.Context, path string, notFound error) ([]Permission, error) {
+	resp, err := client.get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, notFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var permissions []Permission
+	if err := decodeJSON(resp.Body, &permissions); err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+func (client *Client) setPermissions(ctx context.Context, path string, permissions []Permission, notFound error) error {
+	items := make([]Permission, 0, len(permissions))
+	for _, permission := range permissions {
+		if permission.Inherited {
+			continue
+		}
+
+		items = append(items, permission)
+	}
+
+	buf, err := json.Marshal(struct {
+		Items []Permission `json:"items"`
+	}{
+		Items: items,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPost, path, buf)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return notFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
+
+// EnforceDashboardPermissions replaces the permissions set on a dashboard with
+// the ones managed by the builder. Builders that don't manage permissions
+// leave the dashboard untouched.
+func (client *Client) EnforceDashboardPermissions(ctx context.Context, dashboardUID string, builder dashboard.Builder) error {
+	if builder.Permissions == nil {
+		return nil
+	}
+
+	return client.SetDashboardPermissions(ctx, dashboardUID, permissionsFromBuilder(builder.Permissions))
+}
+
+func permissionsFromBuilder(permissions []dashboard.Permission) []Permission {
+	converted := make([]Permission, 0, len(permissions))
+	for _, permission := range permissions {
+		converted = append(converted, Permission{
+			UserID:     permission.UserID,
+			TeamID:     permission.TeamID,
+			Role:       OrgRole(permission.Role),
+			Permission: permission.Level,
+		})
+	}
+
+	return converted
+}
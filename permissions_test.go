@@ -0,0 +1,91 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/K-Phoen/grabana/dashboard"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFolderPermissionsCanBeRead(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/folders/folder-uid/permissions", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `[
+  {"id": 1, "folderId": 4, "role": "Viewer", "permission": 1, "permissionName": "View"},
+  {"id": 2, "folderId": 4, "teamId": 3, "team": "platform", "permission": 2, "permissionName": "Edit"}
+]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	permissions, err := client.GetFolderPermissions(context.TODO(), "folder-uid")
+
+	req.NoError(err)
+	req.Equal([]Permission{
+		{Role: OrgRoleViewer, Permission: dashboard.PermissionView},
+		{TeamID: 3, Permission: dashboard.PermissionEdit},
+	}, permissions)
+}
+
+func TestFetchingPermissionsOfAnUnknownDashboardFailsCleanly(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.GetDashboardPermissions(context.TODO(), "unknown")
+
+	req.ErrorIs(err, ErrDashboardNotFound)
+}
+
+func TestDashboardPermissionsCanBeReplaced(t *testing.T) {
+	req := require.New(t)
+
+	var payload map[string][]map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodPost, r.Method)
+		req.Equal("/api/dashboards/uid/dash-uid/permissions", r.URL.Path)
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+
+		_, _ = fmt.Fprintln(w, `{"message": "Dashboard permissions updated"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.SetDashboardPermissions(context.TODO(), "dash-uid", []Permission{
+		{Role: OrgRoleEditor, Permission: dashboard.PermissionEdit, Inherited: true},
+		{UserID: 7, Permission: dashboard.PermissionAdmin},
+	})
+
+	req.NoError(err)
+	req.Equal(map[string][]map[string]interface{}{
+		"items": {{"userId": float64(7), "permission": float64(4)}},
+	}, payload)
+}
+
+func TestSettingFolderPermissionsCanFail(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = fmt.Fprintln(w, `{"message": "Permission denied"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.SetFolderPermissions(context.TODO(), "folder-uid", nil)
+
+	req.True(IsForbidden(err))
+}
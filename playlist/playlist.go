@@ -0,0 +1,126 @@
+package playlist
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/K-Phoen/grabana/errors"
+)
+
+// ItemType describes how a playlist item selects dashboards.
+type ItemType string
+
+// ItemDashboardByUID selects a single dashboard, given its UID.
+const ItemDashboardByUID ItemType = "dashboard_by_uid"
+
+// ItemDashboardByTag selects every dashboard having the given tag.
+const ItemDashboardByTag ItemType = "dashboard_by_tag"
+
+var intervalRegex = regexp.MustCompile(`^[0-9]+(ms|s|m|h|d|w|M|y)$`)
+
+// Option represents an option that can be used to configure a playlist.
+type Option func(playlist *Builder) error
+
+// Item represents an entry of a playlist.
+type Item struct {
+	Type  ItemType `json:"type"`
+	Value string   `json:"value"`
+}
+
+// Model is the representation of a playlist, as expected by Grafana's API.
+type Model struct {
+	UID      string `json:"uid,omitempty"`
+	Name     string `json:"name"`
+	Interval string `json:"interval"`
+	Items    []Item `json:"items"`
+}
+
+// Builder is the main builder used to configure playlists.
+// See https://grafana.com/docs/grafana/latest/dashboards/create-manage-playlists/
+type Builder struct {
+	playlist *Model
+}
+
+// New creates a new playlist builder.
+func New(name string, options ...Option) (Builder, error) {
+	builder := &Builder{
+		playlist: &Model{
+			Name:     name,
+			Interval: "5m",
+			Items:    []Item{},
+		},
+	}
+
+	for _, opt := range options {
+		if err := opt(builder); err != nil {
+			return *builder, err
+		}
+	}
+
+	return *builder, nil
+}
+
+// MarshalJSON implements the encoding/json.Marshaler interface.
+func (builder *Builder) MarshalJSON() ([]byte, error) {
+	return json.Marshal(builder.playlist)
+}
+
+// MarshalIndentJSON renders the playlist as indented JSON.
+func (builder *Builder) MarshalIndentJSON() ([]byte, error) {
+	return json.MarshalIndent(builder.playlist, "", "  ")
+}
+
+// Internal.
+func (builder *Builder) Internal() *Model {
+	return builder.playlist
+}
+
+// UID sets the UID used by the playlist.
+func UID(uid string) Option {
+	return func(builder *Builder) error {
+		builder.playlist.UID = uid
+
+		return nil
+	}
+}
+
+// Interval sets how long each dashboard is displayed before switching to
+// the next one. Example: "5m".
+func Interval(interval string) Option {
+	return func(builder *Builder) error {
+		if !intervalRegex.MatchString(interval) {
+			return fmt.Errorf("invalid interval '%s': %w", interval, errors.ErrInvalidArgument)
+		}
+
+		builder.playlist.Interval = interval
+
+		return nil
+	}
+}
+
+// DashboardsByUID adds the dashboards identified by the given UIDs to the
+// playlist.
+func DashboardsByUID(uids ...string) Option {
+	return items(ItemDashboardByUID, uids)
+}
+
+// DashboardsByTag adds every dashboard having one of the given tags to the
+// playlist. Dashboards are resolved by Grafana when the playlist starts.
+func DashboardsByTag(tags ...string) Option {
+	return items(ItemDashboardByTag, tags)
+}
+
+func items(itemType ItemType, values []string) Option {
+	return func(builder *Builder) error {
+		for _, value := range values {
+			if value == "" {
+				return fmt.Errorf("playlist item value can not be empty: %w", errors.ErrInvalidArgument)
+			}
+
+			builder.playlist.Items = append(builder.playlist.Items, Item{Type: itemType, Value: value})
+		}
+
+		return nil
+	}
+}
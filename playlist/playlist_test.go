@@ -0,0 +1,86 @@
+package playlist
+
+import (
+	"testing"
+
+	"github.com/K-Phoen/grabana/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPlaylistsCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	playlist, err := New("NOC")
+
+	req.NoError(err)
+	req.Equal("NOC", playlist.Internal().Name)
+	req.Equal("5m", playlist.Internal().Interval)
+	req.Empty(playlist.Internal().Items)
+}
+
+func TestUIDCanBeSet(t *testing.T) {
+	req := require.New(t)
+
+	playlist, err := New("", UID("noc"))
+
+	req.NoError(err)
+	req.Equal("noc", playlist.Internal().UID)
+}
+
+func TestIntervalCanBeSet(t *testing.T) {
+	req := require.New(t)
+
+	playlist, err := New("", Interval("2m"))
+
+	req.NoError(err)
+	req.Equal("2m", playlist.Internal().Interval)
+}
+
+func TestInvalidIntervalsAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", Interval("two minutes"))
+
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestItemsKeepTheirOrder(t *testing.T) {
+	req := require.New(t)
+
+	playlist, err := New("",
+		DashboardsByUID("overview"),
+		DashboardsByTag("noc", "oncall"),
+		DashboardsByUID("database"),
+	)
+
+	req.NoError(err)
+	req.Equal([]Item{
+		{Type: ItemDashboardByUID, Value: "overview"},
+		{Type: ItemDashboardByTag, Value: "noc"},
+		{Type: ItemDashboardByTag, Value: "oncall"},
+		{Type: ItemDashboardByUID, Value: "database"},
+	}, playlist.Internal().Items)
+}
+
+func TestEmptyItemsAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", DashboardsByTag(""))
+
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestPlaylistsCanBeMarshalledIntoJSON(t *testing.T) {
+	req := require.New(t)
+
+	playlist, err := New("NOC", UID("noc"), DashboardsByUID("overview"))
+	req.NoError(err)
+
+	buf, err := playlist.MarshalJSON()
+
+	req.NoError(err)
+	req.JSONEq(`{"uid": "noc", "name": "NOC", "interval": "5m", "items": [{"type": "dashboard_by_uid", "value": "overview"}]}`, string(buf))
+
+	_, err = playlist.MarshalIndentJSON()
+	req.NoError(err)
+}
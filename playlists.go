@@ -0,0 +1,166 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/K-Phoen/grabana/playlist"
+)
+
+// ErrPlaylistNotFound is returned when the given playlist can not be found.
+var ErrPlaylistNotFound = errors.New("playlist not found")
+
+// Playlist represents a playlist, cycling through dashboards.
+// See https://grafana.com/docs/grafana/latest/dashboards/create-manage-playlists/
+type Playlist struct {
+	ID       uint            `json:"id"`
+	UID      string          `json:"uid"`
+	Name     string          `json:"name"`
+	Interval string          `json:"interval"`
+	Items    []playlist.Item `json:"items"`
+}
+
+// UpsertPlaylist creates or replaces a playlist. Playlists are identified
+// by their UID when the builder defines one, by their name otherwise.
+func (client *Client) UpsertPlaylist(ctx context.Context, builder playlist.Builder) (*Playlist, error) {
+	model := *builder.Internal()
+
+	var existing *Playlist
+	var err error
+	if model.UID != "" {
+		existing, err = client.GetPlaylistByUID(ctx, model.UID)
+	} else {
+		existing, err = client.GetPlaylistByName(ctx, model.Name)
+	}
+	if err != nil && !errors.Is(err, ErrPlaylistNotFound) {
+		return nil, err
+	}
+
+	method := http.MethodPost
+	path := "/api/playlists"
+	if existing != nil {
+		method = http.MethodPut
+		path = "/api/playlists/" + url.PathEscape(existing.UID)
+		model.UID = existing.UID
+	}
+
+	buf, err := json.Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.sendJSON(ctx, method, path, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	created := &Playlist{}
+	if err := decodeJSON(resp.Body, created); err != nil {
+		return nil, err
+	}
+	// depending on its version, Grafana doesn't always send the items back
+	if created.Items == nil {
+		created.Items = model.Items
+	}
+
+	return created, nil
+}
+
+// GetPlaylistByUID finds a playlist, given its UID.
+func (client *Client) GetPlaylistByUID(ctx context.Context, uid string) (*Playlist, error) {
+	resp, err := client.get(ctx, "/api/playlists/"+url.PathEscape(uid))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrPlaylistNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	found := &Playlist{}
+	if err := decodeJSON(resp.Body, found); err != nil {
+		return nil, err
+	}
+
+	return found, nil
+}
+
+// GetPlaylistByName finds a playlist, given its name.
+func (client *Client) GetPlaylistByName(ctx context.Context, name string) (*Playlist, error) {
+	playlists, err := client.searchPlaylists(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range playlists {
+		if playlists[i].Name == name {
+			return &playlists[i], nil
+		}
+	}
+
+	return nil, ErrPlaylistNotFound
+}
+
+// ListPlaylists returns the playlists of the current organization. Their
+// items are not included.
+func (client *Client) ListPlaylists(ctx context.Context) ([]Playlist, error) {
+	return client.searchPlaylists(ctx, "")
+}
+
+func (client *Client) searchPlaylists(ctx context.Context, query string) ([]Playlist, error) {
+	params := url.Values{"limit": []string{"1000"}}
+	if query != "" {
+		params.Set("query", query)
+	}
+
+	resp, err := client.get(ctx, "/api/playlists?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var playlists []Playlist
+	if err := decodeJSON(resp.Body, &playlists); err != nil {
+		return nil, err
+	}
+
+	return playlists, nil
+}
+
+// DeletePlaylist deletes a playlist given its UID.
+func (client *Client) DeletePlaylist(ctx context.Context, uid string) error {
+	resp, err := client.delete(ctx, "/api/playlists/"+url.PathEscape(uid))
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrPlaylistNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/K-Phoen/grabana/playlist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertingAnUnknownPlaylistCreatesIt(t *testing.T) {
+	req := require.New(t)
+
+	var payload map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/playlists":
+			req.Equal("NOC", r.URL.Query().Get("query"))
+			_, _ = fmt.Fprintln(w, `[{"id": 1, "uid": "other", "name": "NOC (old)"}]`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/playlists":
+			req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+			_, _ = fmt.Fprintln(w, `{"id": 2, "uid": "generated", "name": "NOC", "interval": "5m"}`)
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+	builder, err := playlist.New("NOC", playlist.DashboardsByTag("noc"))
+	req.NoError(err)
+
+	created, err := client.UpsertPlaylist(context.TODO(), builder)
+
+	req.NoError(err)
+	req.Equal("generated", created.UID)
+	req.Equal("NOC", payload["name"])
+	req.NotContains(payload, "uid")
+	req.Len(created.Items, 1)
+}
+
+func TestUpsertingAKnownPlaylistReplacesIt(t *testing.T) {
+	req := require.New(t)
+
+	var payload map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/playlists/noc":
+			_, _ = fmt.Fprintln(w, `{"id": 1, "uid": "noc", "name": "NOC", "interval": "5m", "items": []}`)
+		case r.Method == http.MethodPut && r.URL.Path == "/api/playlists/noc":
+			req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+			_, _ = fmt.Fprintln(w, `{"id": 1, "uid": "noc", "name": "NOC wall", "interval": "1m", "items": [{"type": "dashboard_by_uid", "value": "overview"}]}`)
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+	builder, err := playlist.New("NOC wall", playlist.UID("noc"), playlist.Interval("1m"), playlist.DashboardsByUID("overview"))
+	req.NoError(err)
+
+	updated, err := client.UpsertPlaylist(context.TODO(), builder)
+
+	req.NoError(err)
+	req.Equal("1m", payload["interval"])
+	req.Equal("NOC wall", updated.Name)
+	req.Equal([]playlist.Item{{Type: playlist.ItemDashboardByUID, Value: "overview"}}, updated.Items)
+}
+
+func TestGettingAnUnknownPlaylistByNameFails(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.GetPlaylistByName(context.TODO(), "NOC")
+
+	req.ErrorIs(err, ErrPlaylistNotFound)
+}
+
+func TestDeletingAnUnknownPlaylistFailsCleanly(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodDelete, r.Method)
+		req.Equal("/api/playlists/unknown", r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.DeletePlaylist(context.TODO(), "unknown")
+
+	req.ErrorIs(err, ErrPlaylistNotFound)
+}
@@ -0,0 +1,184 @@
+package grabana
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type retryPolicy struct {
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+// WithRetries sets up the client to retry idempotent requests (GET, PUT and
+// DELETE, as well as dashboard upserts overwriting the existing version) when
+// Grafana is unreachable or answers with a 429, 502, 503 or 504 status code.
+// Retries are delayed using an exponential backoff with jitter, bounded by
+// minBackoff and maxBackoff. A Retry-After header sent by Grafana takes
+// precedence over the computed delay.
+func WithRetries(maxRetries int, minBackoff time.Duration, maxBackoff time.Duration) Option {
+	return func(client *Client) {
+		client.retry = &retryPolicy{
+			maxRetries: maxRetries,
+			minBackoff: minBackoff,
+			maxBackoff: maxBackoff,
+		}
+	}
+}
+
+// WithRateLimit sets up the client to send at most requestsPerSecond
+// requests per second to Grafana. Retries count against that limit too.
+func WithRateLimit(requestsPerSecond float64) Option {
+	return func(client *Client) {
+		if requestsPerSecond <= 0 {
+			client.limiter = nil
+			return
+		}
+
+		client.limiter = &rateLimiter{
+			interval: time.Duration(float64(time.Second) / requestsPerSecond),
+		}
+	}
+}
+
+func (client Client) doWithRetries(ctx context.Context, retryable bool, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	maxRetries := 0
+	if client.retry != nil && retryable {
+		maxRetries = client.retry.maxRetries
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := client.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+
+		request, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.http.Do(request)
+		if attempt >= maxRetries || !shouldRetry(ctx, resp, err) {
+			return resp, err
+		}
+
+		delay := client.retry.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				delay = retryAfter
+			}
+
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func isIdempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodPut || method == http.MethodDelete
+}
+
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		// errors caused by the context being canceled are final
+		return ctx.Err() == nil
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff computes an exponential delay with "equal jitter": half of the
+// delay is fixed, the other half is random.
+func (policy *retryPolicy) backoff(attempt int) time.Duration {
+	delay := policy.minBackoff
+	for i := 0; i < attempt && delay < policy.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > policy.maxBackoff {
+		delay = policy.maxBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	half := delay / 2
+
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+
+		return delay, true
+	}
+
+	return 0, false
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rateLimiter spaces requests evenly, at most one every interval.
+type rateLimiter struct {
+	interval time.Duration
+
+	lock sync.Mutex
+	next time.Time
+}
+
+func (limiter *rateLimiter) wait(ctx context.Context) error {
+	if limiter == nil {
+		return nil
+	}
+
+	limiter.lock.Lock()
+	now := time.Now()
+	slot := limiter.next
+	if slot.Before(now) {
+		slot = now
+	}
+	limiter.next = slot.Add(limiter.interval)
+	limiter.lock.Unlock()
+
+	return sleep(ctx, time.Until(slot))
+}
@@ -0,0 +1,147 @@
+package grabana
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotentRequestsAreRetried(t *testing.T) {
+	req := require.New(t)
+
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		_, _ = fmt.Fprintln(w, `[{"id": 1, "uid": "folder-uid", "title": "Folder"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL, WithRetries(3, time.Millisecond, 5*time.Millisecond))
+
+	folders, err := client.ListFolders(context.TODO())
+
+	req.NoError(err)
+	req.Len(folders, 1)
+	req.Equal(3, calls)
+}
+
+func TestRetriesGiveUpAfterMaxRetries(t *testing.T) {
+	req := require.New(t)
+
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL, WithRetries(2, time.Millisecond, time.Millisecond))
+
+	_, err := client.ListFolders(context.TODO())
+
+	req.True(IsServerError(err))
+	req.Equal(3, calls)
+}
+
+func TestNonIdempotentRequestsAreNotRetried(t *testing.T) {
+	req := require.New(t)
+
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL, WithRetries(3, time.Millisecond, time.Millisecond))
+
+	_, err := client.CreateFolder(context.TODO(), "Folder")
+
+	req.Error(err)
+	req.Equal(1, calls)
+}
+
+func TestRetryAfterHeaderIsHonored(t *testing.T) {
+	req := require.New(t)
+
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		_, _ = fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL, WithRetries(3, time.Millisecond, time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.ListFolders(ctx)
+
+	req.ErrorIs(err, context.DeadlineExceeded)
+	req.Equal(1, calls)
+}
+
+func TestRetryAfterParsing(t *testing.T) {
+	req := require.New(t)
+
+	delay, ok := parseRetryAfter("3")
+	req.True(ok)
+	req.Equal(3*time.Second, delay)
+
+	delay, ok = parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	req.True(ok)
+	req.Equal(time.Duration(0), delay)
+
+	_, ok = parseRetryAfter("soon")
+	req.False(ok)
+}
+
+func TestBackoffIsBounded(t *testing.T) {
+	req := require.New(t)
+
+	policy := &retryPolicy{minBackoff: 10 * time.Millisecond, maxBackoff: 40 * time.Millisecond}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		delay := policy.backoff(attempt)
+
+		req.GreaterOrEqual(delay, 5*time.Millisecond)
+		req.LessOrEqual(delay, 40*time.Millisecond)
+	}
+}
+
+func TestRequestsCanBeRateLimited(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL, WithRateLimit(100))
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		_, err := client.ListFolders(context.TODO())
+		req.NoError(err)
+	}
+
+	// the first request goes through immediately, the next four are spaced by 10ms
+	req.GreaterOrEqual(time.Since(start), 40*time.Millisecond)
+}
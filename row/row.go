@@ -5,6 +5,7 @@ import (
 	"github.com/K-Phoen/grabana/gauge"
 	"github.com/K-Phoen/grabana/graph"
 	"github.com/K-Phoen/grabana/heatmap"
+	"github.com/K-Phoen/grabana/librarypanel"
 	"github.com/K-Phoen/grabana/logs"
 	alert "github.com/K-Phoen/grabana/ngalert"
 	"github.com/K-Phoen/grabana/singlestat"
@@ -195,6 +196,20 @@ func WithHeatmap(title string, options ...heatmap.Option) Option {
 	}
 }
 
+// WithLibraryPanel adds a reference to a library panel in the row.
+func WithLibraryPanel(uid string, options ...librarypanel.Option) Option {
+	return func(row *Row) error {
+		panel, err := librarypanel.New(uid, options...)
+		if err != nil {
+			return err
+		}
+
+		row.builder.Add(panel.Builder)
+
+		return nil
+	}
+}
+
 // ShowTitle ensures that the title of the row will be displayed.
 func ShowTitle() Option {
 	return func(row *Row) error {
@@ -89,6 +89,26 @@ func TestRowsCanHaveTextPanels(t *testing.T) {
 	req.Len(panel.builder.Panels, 1)
 }
 
+func TestRowsCanHaveLibraryPanels(t *testing.T) {
+	req := require.New(t)
+	board := sdk.NewBoard("")
+
+	panel, err := New(board, "", WithLibraryPanel("slo-burn-rate"))
+
+	req.NoError(err)
+	req.Len(panel.builder.Panels, 1)
+	req.Contains(*panel.builder.Panels[0].CustomPanel, "libraryPanel")
+}
+
+func TestRowsCanNotHaveInvalidLibraryPanels(t *testing.T) {
+	req := require.New(t)
+	board := sdk.NewBoard("")
+
+	_, err := New(board, "", WithLibraryPanel(""))
+
+	req.Error(err)
+}
+
 func TestRowsCanHaveTablePanels(t *testing.T) {
 	req := require.New(t)
 	board := sdk.NewBoard("")
@@ -0,0 +1,122 @@
+package rulegroup
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/K-Phoen/grabana/errors"
+	alert "github.com/K-Phoen/grabana/ngalert"
+	"github.com/K-Phoen/sdk"
+)
+
+// Option represents an option that can be used to configure a rule group.
+type Option func(group *Builder) error
+
+// Model is the representation of a rule group, as expected by Grafana's API.
+type Model struct {
+	Title     string `json:"title"`
+	FolderUID string `json:"folderUid"`
+	// Interval is the evaluation interval of the group, in seconds.
+	Interval int64         `json:"interval"`
+	Rules    []sdk.NgAlert `json:"rules"`
+}
+
+// Builder is the main builder used to configure alert rule groups that are
+// not tied to a dashboard.
+// See https://grafana.com/docs/grafana/latest/alerting/alerting-rules/create-grafana-managed-rule/
+type Builder struct {
+	group *Model
+}
+
+// New creates a new rule group builder. The folder the group belongs to is
+// required.
+func New(name string, options ...Option) (Builder, error) {
+	builder := &Builder{
+		group: &Model{
+			Title:    name,
+			Interval: 60,
+			Rules:    []sdk.NgAlert{},
+		},
+	}
+
+	for _, opt := range options {
+		if err := opt(builder); err != nil {
+			return *builder, err
+		}
+	}
+
+	if builder.group.FolderUID == "" {
+		return *builder, fmt.Errorf("rule group '%s' has no folder: %w", name, errors.ErrInvalidArgument)
+	}
+
+	// rules always belong to the group they are defined in
+	for i := range builder.group.Rules {
+		builder.group.Rules[i].RuleGroup = builder.group.Title
+		builder.group.Rules[i].FolderUID = builder.group.FolderUID
+	}
+
+	return *builder, nil
+}
+
+// MarshalJSON implements the encoding/json.Marshaler interface.
+func (builder *Builder) MarshalJSON() ([]byte, error) {
+	return json.Marshal(builder.group)
+}
+
+// MarshalIndentJSON renders the rule group as indented JSON.
+func (builder *Builder) MarshalIndentJSON() ([]byte, error) {
+	return json.MarshalIndent(builder.group, "", "  ")
+}
+
+// Internal.
+func (builder *Builder) Internal() *Model {
+	return builder.group
+}
+
+// FolderUID sets the UID of the folder the group belongs to.
+func FolderUID(uid string) Option {
+	return func(builder *Builder) error {
+		builder.group.FolderUID = uid
+
+		return nil
+	}
+}
+
+// Interval sets how often the rules of the group are evaluated. It must be
+// expressed in whole seconds.
+func Interval(interval time.Duration) Option {
+	return func(builder *Builder) error {
+		if interval < time.Second || interval%time.Second != 0 {
+			return fmt.Errorf("invalid interval '%s': %w", interval, errors.ErrInvalidArgument)
+		}
+
+		builder.group.Interval = int64(interval / time.Second)
+
+		return nil
+	}
+}
+
+// Rules adds alert rules to the group. Rules are evaluated in the order
+// they are given. Titles must be unique within a group.
+func Rules(alerts ...*alert.Alert) Option {
+	return func(builder *Builder) error {
+		for _, rule := range alerts {
+			for _, existing := range builder.group.Rules {
+				if strings.EqualFold(existing.Title, rule.Builder.Title) {
+					return fmt.Errorf("duplicate rule '%s': %w", rule.Builder.Title, errors.ErrInvalidArgument)
+				}
+			}
+
+			// copy the rule so that the alert given by the caller is never
+			// altered by the group
+			model := *rule.Builder
+			model.Data = append([]sdk.NgAlertQuery(nil), rule.Builder.Data...)
+
+			builder.group.Rules = append(builder.group.Rules, model)
+		}
+
+		return nil
+	}
+}
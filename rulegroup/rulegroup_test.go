@@ -0,0 +1,128 @@
+package rulegroup
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/K-Phoen/grabana/errors"
+	alert "github.com/K-Phoen/grabana/ngalert"
+	"github.com/K-Phoen/grabana/ngalert/query"
+	"github.com/stretchr/testify/require"
+)
+
+func testRule(title string) *alert.Alert {
+	return alert.New(
+		title,
+		alert.Query("A", query.Datasource("Prometheus"), query.Expr("up == 0"), query.AlertCondition()),
+	)
+}
+
+func TestNewRuleGroupsCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	group, err := New("Infrastructure", FolderUID("infra"))
+
+	req.NoError(err)
+	req.Equal("Infrastructure", group.Internal().Title)
+	req.Equal("infra", group.Internal().FolderUID)
+	req.Equal(int64(60), group.Internal().Interval)
+	req.Empty(group.Internal().Rules)
+}
+
+func TestRuleGroupsRequireAFolder(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("Infrastructure")
+
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestIntervalCanBeSet(t *testing.T) {
+	req := require.New(t)
+
+	group, err := New("", FolderUID("infra"), Interval(2*time.Minute))
+
+	req.NoError(err)
+	req.Equal(int64(120), group.Internal().Interval)
+}
+
+func TestInvalidIntervalsAreRejected(t *testing.T) {
+	testCases := []time.Duration{0, -time.Minute, 1500 * time.Millisecond}
+
+	for _, interval := range testCases {
+		_, err := New("", FolderUID("infra"), Interval(interval))
+
+		require.ErrorIs(t, err, errors.ErrInvalidArgument, interval.String())
+	}
+}
+
+func TestRulesAreAddedInOrderAndBelongToTheGroup(t *testing.T) {
+	req := require.New(t)
+
+	group, err := New(
+		"Infrastructure",
+		Rules(testRule("Node down"), testRule("Disk full")),
+		FolderUID("infra"),
+		Rules(testRule("Memory pressure")),
+	)
+
+	req.NoError(err)
+
+	rules := group.Internal().Rules
+	req.Len(rules, 3)
+	req.Equal("Node down", rules[0].Title)
+	req.Equal("Disk full", rules[1].Title)
+	req.Equal("Memory pressure", rules[2].Title)
+
+	for _, rule := range rules {
+		req.Equal("Infrastructure", rule.RuleGroup)
+		req.Equal("infra", rule.FolderUID)
+	}
+}
+
+func TestRulesAreCopied(t *testing.T) {
+	req := require.New(t)
+
+	rule := testRule("Node down")
+
+	group, err := New("Infrastructure", FolderUID("infra"), Rules(rule))
+	req.NoError(err)
+
+	group.Internal().Rules[0].Data[0].DatasourceUid = "prom-uid"
+
+	req.Empty(rule.Builder.RuleGroup)
+	req.Equal("Prometheus", rule.Builder.Data[0].DatasourceUid)
+}
+
+func TestDuplicateRulesAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("Infrastructure", FolderUID("infra"), Rules(testRule("Node down"), testRule("node down")))
+
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestRuleGroupsCanBeMarshalledIntoJSON(t *testing.T) {
+	req := require.New(t)
+
+	group, err := New("Infrastructure", FolderUID("infra"), Rules(testRule("Node down")))
+	req.NoError(err)
+
+	buf, err := group.MarshalIndentJSON()
+	req.NoError(err)
+
+	payload := struct {
+		Title     string                   `json:"title"`
+		FolderUID string                   `json:"folderUid"`
+		Interval  int64                    `json:"interval"`
+		Rules     []map[string]interface{} `json:"rules"`
+	}{}
+	req.NoError(json.Unmarshal(buf, &payload))
+
+	req.Equal("Infrastructure", payload.Title)
+	req.Equal("infra", payload.FolderUID)
+	req.Equal(int64(60), payload.Interval)
+	req.Len(payload.Rules, 1)
+	req.Equal("Node down", payload.Rules[0]["title"])
+}
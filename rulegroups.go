@@ -0,0 +1,137 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	alert "github.com/K-Phoen/grabana/ngalert"
+	"github.com/K-Phoen/grabana/rulegroup"
+	"github.com/K-Phoen/sdk"
+)
+
+// ErrRuleGroupNotFound is returned when the given rule group can not be found.
+var ErrRuleGroupNotFound = errors.New("rule group not found")
+
+// RuleGroup represents a group of alert rules, evaluated together.
+type RuleGroup struct {
+	Title     string `json:"title"`
+	FolderUID string `json:"folderUid"`
+	// Interval is the evaluation interval of the group, in seconds.
+	Interval int64         `json:"interval"`
+	Rules    []sdk.NgAlert `json:"rules"`
+}
+
+func ruleGroupPath(folderUID string, name string) string {
+	return fmt.Sprintf("/api/v1/provisioning/folder/%s/rule-groups/%s", url.PathEscape(folderUID), url.PathEscape(name))
+}
+
+// GetRuleGroup finds a rule group, given the UID of its folder and its name.
+func (client *Client) GetRuleGroup(ctx context.Context, folderUID string, name string) (*RuleGroup, error) {
+	resp, err := client.get(ctx, ruleGroupPath(folderUID, name))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrRuleGroupNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	group := &RuleGroup{}
+	if err := decodeJSON(resp.Body, group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// UpsertRuleGroup creates or replaces a rule group. The whole group is
+// replaced atomically: rules are matched with existing ones by title, and
+// existing rules that are no longer defined are deleted.
+// Unlike alerts defined in dashboards, rule groups are not tied to any
+// dashboard.
+func (client *Client) UpsertRuleGroup(ctx context.Context, builder rulegroup.Builder) error {
+	model := *builder.Internal()
+
+	existing, err := client.GetRuleGroup(ctx, model.FolderUID, model.Title)
+	if err != nil && !errors.Is(err, ErrRuleGroupNotFound) {
+		return err
+	}
+
+	uidByTitle := map[string]string{}
+	if existing != nil {
+		for _, rule := range existing.Rules {
+			uidByTitle[strings.ToLower(rule.Title)] = rule.Uid
+		}
+	}
+
+	datasourcesMap, err := client.datasourcesUIDMap(ctx)
+	if err != nil {
+		return err
+	}
+
+	rules := make([]sdk.NgAlert, 0, len(model.Rules))
+	for _, rule := range model.Rules {
+		// the builder is left untouched, so that it can be upserted again
+		rule.Data = append([]sdk.NgAlertQuery(nil), rule.Data...)
+
+		alertObj := alert.Alert{Builder: &rule}
+		if err := alertObj.HookDatasource(datasourcesMap); err != nil {
+			return fmt.Errorf("could not prepare alert (%s) for rule group: %w", rule.Title, err)
+		}
+
+		if rule.Uid == "" {
+			rule.Uid = uidByTitle[strings.ToLower(rule.Title)]
+		}
+
+		rules = append(rules, rule)
+	}
+	model.Rules = rules
+
+	buf, err := json.Marshal(model)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPut, ruleGroupPath(model.FolderUID, model.Title), buf)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
+
+// DeleteRuleGroup deletes a rule group and all its rules, given the UID of
+// its folder and its name.
+func (client *Client) DeleteRuleGroup(ctx context.Context, folderUID string, name string) error {
+	resp, err := client.delete(ctx, ruleGroupPath(folderUID, name))
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrRuleGroupNotFound
+	}
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	alert "github.com/K-Phoen/grabana/ngalert"
+	"github.com/K-Phoen/grabana/ngalert/query"
+	"github.com/K-Phoen/grabana/rulegroup"
+	"github.com/stretchr/testify/require"
+)
+
+func testRuleGroup(t *testing.T, titles ...string) rulegroup.Builder {
+	rules := make([]*alert.Alert, 0, len(titles))
+	for _, title := range titles {
+		rules = append(rules, alert.New(
+			title,
+			alert.Query("A", query.Datasource("Prometheus"), query.Expr("up == 0"), query.AlertCondition()),
+		))
+	}
+
+	group, err := rulegroup.New("Infrastructure", rulegroup.FolderUID("infra"), rulegroup.Interval(2*time.Minute), rulegroup.Rules(rules...))
+	require.NoError(t, err)
+
+	return group
+}
+
+func TestUpsertingARuleGroupReplacesItWholly(t *testing.T) {
+	req := require.New(t)
+
+	payload := RuleGroup{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/datasources":
+			_, _ = fmt.Fprintln(w, `[{"id": 1, "uid": "prom-uid", "name": "Prometheus"}]`)
+		case r.Method == http.MethodGet:
+			req.Equal("/api/v1/provisioning/folder/infra/rule-groups/Infrastructure", r.URL.Path)
+			_, _ = fmt.Fprintln(w, `{"title": "Infrastructure", "folderUid": "infra", "interval": 60, "rules": [
+	{"uid": "node-down-uid", "title": "Node Down"},
+	{"uid": "stale-uid", "title": "Stale"}
+]}`)
+		case r.Method == http.MethodPut:
+			req.Equal("/api/v1/provisioning/folder/infra/rule-groups/Infrastructure", r.URL.Path)
+			req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+			_, _ = fmt.Fprintln(w, `{}`)
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+	group := testRuleGroup(t, "Node down", "Disk full")
+
+	err := client.UpsertRuleGroup(context.TODO(), group)
+
+	req.NoError(err)
+	req.Equal("Infrastructure", payload.Title)
+	req.Equal("infra", payload.FolderUID)
+	req.Equal(int64(120), payload.Interval)
+	req.Len(payload.Rules, 2)
+	req.Equal("Node down", payload.Rules[0].Title)
+	req.Equal("node-down-uid", payload.Rules[0].Uid)
+	req.Equal("prom-uid", payload.Rules[0].Data[0].DatasourceUid)
+	req.Equal("Disk full", payload.Rules[1].Title)
+	req.Empty(payload.Rules[1].Uid)
+	req.Equal("Infrastructure", payload.Rules[1].RuleGroup)
+	req.Equal("infra", payload.Rules[1].FolderUID)
+
+	// the builder is left untouched
+	req.Equal("Prometheus", group.Internal().Rules[0].Data[0].DatasourceUid)
+}
+
+func TestUpsertingARuleGroupFailsWithUnknownDatasources(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/datasources":
+			_, _ = fmt.Fprintln(w, `[]`)
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.UpsertRuleGroup(context.TODO(), testRuleGroup(t, "Node down"))
+
+	req.Error(err)
+	req.Contains(err.Error(), "Prometheus")
+}
+
+func TestGetRuleGroup(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/v1/provisioning/folder/infra/rule-groups/Infrastructure", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `{"title": "Infrastructure", "folderUid": "infra", "interval": 60, "rules": [{"uid": "node-down-uid", "title": "Node down"}]}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	group, err := client.GetRuleGroup(context.TODO(), "infra", "Infrastructure")
+
+	req.NoError(err)
+	req.Equal("Infrastructure", group.Title)
+	req.Equal(int64(60), group.Interval)
+	req.Len(group.Rules, 1)
+	req.Equal("node-down-uid", group.Rules[0].Uid)
+}
+
+func TestGetRuleGroupReturnsNotFoundErrors(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.GetRuleGroup(context.TODO(), "infra", "Infrastructure")
+
+	req.ErrorIs(err, ErrRuleGroupNotFound)
+	req.True(IsNotFound(err))
+}
+
+func TestDeleteRuleGroup(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodDelete, r.Method)
+		req.Equal("/api/v1/provisioning/folder/infra/rule-groups/Infrastructure", r.URL.Path)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.DeleteRuleGroup(context.TODO(), "infra", "Infrastructure")
+
+	req.NoError(err)
+}
+
+func TestDeletingAnUnknownRuleGroupReturnsNotFoundErrors(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.DeleteRuleGroup(context.TODO(), "infra", "Infrastructure")
+
+	req.ErrorIs(err, ErrRuleGroupNotFound)
+}
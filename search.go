@@ -0,0 +1,126 @@
+package grabana
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// SearchType restricts the kind of results returned by a search.
+type SearchType string
+
+const (
+	SearchTypeDashboard SearchType = "dash-db"
+	SearchTypeFolder    SearchType = "dash-folder"
+)
+
+// defaultSearchLimit is the number of results requested per page when none
+// is specified.
+const defaultSearchLimit = 1000
+
+// maxSearchLimit is the maximum number of results Grafana returns per page.
+const maxSearchLimit = 5000
+
+// SearchDashboardsQuery describes the criteria used to search dashboards.
+// Empty criteria are ignored.
+type SearchDashboardsQuery struct {
+	// Query is matched against the title of the dashboards.
+	Query string
+	// Tags only keeps dashboards having all the given tags.
+	Tags          []string
+	FolderUIDs    []string
+	DashboardUIDs []string
+	// Starred only keeps dashboards starred by the current user.
+	Starred bool
+	// Type defaults to SearchTypeDashboard.
+	Type SearchType
+	// Limit is the number of results fetched per page. Defaults to 1000 and
+	// can not exceed 5000.
+	Limit int
+	// Page is the first page to fetch. Defaults to 1.
+	Page int
+}
+
+func (query SearchDashboardsQuery) values(page int, limit int) url.Values {
+	values := url.Values{}
+
+	searchType := query.Type
+	if searchType == "" {
+		searchType = SearchTypeDashboard
+	}
+	values.Set("type", string(searchType))
+
+	if query.Query != "" {
+		values.Set("query", query.Query)
+	}
+	for _, tag := range query.Tags {
+		values.Add("tag", tag)
+	}
+	for _, uid := range query.FolderUIDs {
+		values.Add("folderUIDs", uid)
+	}
+	for _, uid := range query.DashboardUIDs {
+		values.Add("dashboardUIDs", uid)
+	}
+	if query.Starred {
+		values.Set("starred", "true")
+	}
+
+	values.Set("limit", strconv.Itoa(limit))
+	values.Set("page", strconv.Itoa(page))
+
+	return values
+}
+
+// SearchDashboards lists the dashboards matching the given query. Results
+// are fetched page by page until every one of them is retrieved.
+func (client *Client) SearchDashboards(ctx context.Context, query SearchDashboardsQuery) ([]Dashboard, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	page := query.Page
+	if page <= 0 {
+		page = 1
+	}
+
+	dashboards := []Dashboard{}
+
+	for ; ; page++ {
+		results, err := client.searchPage(ctx, query.values(page, limit))
+		if err != nil {
+			return nil, err
+		}
+
+		dashboards = append(dashboards, results...)
+
+		if len(results) < limit {
+			return dashboards, nil
+		}
+	}
+}
+
+func (client *Client) searchPage(ctx context.Context, values url.Values) ([]Dashboard, error) {
+	resp, err := client.get(ctx, "/api/search?"+values.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var dashboards []Dashboard
+	if err := decodeJSON(resp.Body, &dashboards); err != nil {
+		return nil, err
+	}
+
+	return dashboards, nil
+}
@@ -0,0 +1,109 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchDashboardsForwardsTheCriteria(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		req.Equal("/api/search", r.URL.Path)
+		req.Equal("dash-db", query.Get("type"))
+		req.Equal("service", query.Get("query"))
+		req.Equal([]string{"prod", "team-a"}, query["tag"])
+		req.Equal([]string{"folder-a", "folder-b"}, query["folderUIDs"])
+		req.Equal([]string{"dash-uid"}, query["dashboardUIDs"])
+		req.Equal("true", query.Get("starred"))
+		req.Equal("1000", query.Get("limit"))
+		req.Equal("1", query.Get("page"))
+
+		_, _ = fmt.Fprintln(w, `[{"id": 1, "uid": "dash-uid", "title": "Service"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	dashboards, err := client.SearchDashboards(context.TODO(), SearchDashboardsQuery{
+		Query:         "service",
+		Tags:          []string{"prod", "team-a"},
+		FolderUIDs:    []string{"folder-a", "folder-b"},
+		DashboardUIDs: []string{"dash-uid"},
+		Starred:       true,
+	})
+
+	req.NoError(err)
+	req.Len(dashboards, 1)
+	req.Equal("dash-uid", dashboards[0].UID)
+}
+
+func TestSearchDashboardsIteratesOverPages(t *testing.T) {
+	req := require.New(t)
+
+	var requestedPages []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		req.NoError(err)
+		req.Equal("2", r.URL.Query().Get("limit"))
+		requestedPages = append(requestedPages, r.URL.Query().Get("page"))
+
+		// 5 dashboards in total, served 2 by 2
+		hits := []Dashboard{}
+		for id := (page-1)*2 + 1; id <= page*2 && id <= 5; id++ {
+			hits = append(hits, Dashboard{ID: id, UID: fmt.Sprintf("dash-%d", id)})
+		}
+
+		_ = json.NewEncoder(w).Encode(hits)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	dashboards, err := client.SearchDashboards(context.TODO(), SearchDashboardsQuery{Limit: 2})
+
+	req.NoError(err)
+	req.Len(dashboards, 5)
+	req.Equal("dash-5", dashboards[4].UID)
+	req.Equal([]string{"1", "2", "3"}, requestedPages)
+}
+
+func TestSearchDashboardsLimitIsCapped(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("5000", r.URL.Query().Get("limit"))
+
+		_, _ = fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.SearchDashboards(context.TODO(), SearchDashboardsQuery{Limit: 10000})
+
+	req.NoError(err)
+}
+
+func TestSearchDashboardsCanFail(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = fmt.Fprintln(w, `{"message": "Permission denied"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.SearchDashboards(context.TODO(), SearchDashboardsQuery{})
+
+	req.Error(err)
+	req.True(IsForbidden(err))
+}
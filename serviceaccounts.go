@@ -0,0 +1,285 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ErrServiceAccountNotFound is returned when the given service account can not be found.
+var ErrServiceAccountNotFound = errors.New("service account not found")
+
+// CreateServiceAccountRequest represents a request made to the service account creation endpoint.
+type CreateServiceAccountRequest struct {
+	Name       string  `json:"name"`
+	Role       OrgRole `json:"role"`
+	IsDisabled bool    `json:"isDisabled"`
+}
+
+// ServiceAccount represents a service account.
+// See https://grafana.com/docs/grafana/latest/administration/service-accounts/
+type ServiceAccount struct {
+	ID         uint    `json:"id"`
+	Name       string  `json:"name"`
+	Login      string  `json:"login"`
+	OrgID      uint    `json:"orgId"`
+	Role       OrgRole `json:"role"`
+	IsDisabled bool    `json:"isDisabled"`
+	Tokens     int     `json:"tokens"`
+}
+
+// CreateServiceAccountTokenRequest represents a request made to the service account token creation endpoint.
+// A SecondsToLive of zero creates a token that never expires.
+type CreateServiceAccountTokenRequest struct {
+	Name          string `json:"name"`
+	SecondsToLive int    `json:"secondsToLive,omitempty"`
+}
+
+// ServiceAccountToken represents a token belonging to a service account.
+// Key is only known when the token is created.
+type ServiceAccountToken struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Key        string     `json:"key,omitempty"`
+	Created    *time.Time `json:"created,omitempty"`
+	Expiration *time.Time `json:"expiration,omitempty"`
+	HasExpired bool       `json:"hasExpired"`
+}
+
+// APIKeysMigrationResult describes the outcome of a migration of API keys to
+// service accounts.
+type APIKeysMigrationResult struct {
+	Total           int      `json:"total"`
+	Migrated        int      `json:"migrated"`
+	Failed          int      `json:"failed"`
+	FailedAPIKeyIDs []uint   `json:"failedApikeyIDs"`
+	FailedDetails   []string `json:"failedDetails"`
+}
+
+// CreateServiceAccount creates a new service account.
+func (client *Client) CreateServiceAccount(ctx context.Context, request CreateServiceAccountRequest) (*ServiceAccount, error) {
+	buf, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPost, "/api/serviceaccounts", buf)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var account ServiceAccount
+	if err := decodeJSON(resp.Body, &account); err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+// ListServiceAccounts lists the service accounts of the current organization.
+func (client *Client) ListServiceAccounts(ctx context.Context) ([]ServiceAccount, error) {
+	return client.searchServiceAccounts(ctx, "")
+}
+
+// GetServiceAccountByName finds a service account, given its name.
+func (client *Client) GetServiceAccountByName(ctx context.Context, name string) (*ServiceAccount, error) {
+	accounts, err := client.searchServiceAccounts(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range accounts {
+		if accounts[i].Name == name {
+			return &accounts[i], nil
+		}
+	}
+
+	return nil, ErrServiceAccountNotFound
+}
+
+func (client *Client) searchServiceAccounts(ctx context.Context, query string) ([]ServiceAccount, error) {
+	const perPage = 100
+
+	var accounts []ServiceAccount
+
+	for page := 1; ; page++ {
+		resp, err := client.get(ctx, fmt.Sprintf("/api/serviceaccounts/search?perpage=%d&page=%d&query=%s", perPage, page, url.QueryEscape(query)))
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			err := client.httpError(resp)
+			_ = resp.Body.Close()
+
+			return nil, err
+		}
+
+		var response struct {
+			TotalCount      int              `json:"totalCount"`
+			ServiceAccounts []ServiceAccount `json:"serviceAccounts"`
+		}
+		err = decodeJSON(resp.Body, &response)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		accounts = append(accounts, response.ServiceAccounts...)
+
+		if len(response.ServiceAccounts) < perPage || len(accounts) >= response.TotalCount {
+			return accounts, nil
+		}
+	}
+}
+
+// DeleteServiceAccount deletes a service account, along with its tokens.
+func (client *Client) DeleteServiceAccount(ctx context.Context, serviceAccountID uint) error {
+	resp, err := client.delete(ctx, fmt.Sprintf("/api/serviceaccounts/%d", serviceAccountID))
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrServiceAccountNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
+
+// CreateServiceAccountToken creates a new token for the given service account.
+func (client *Client) CreateServiceAccountToken(ctx context.Context, serviceAccountID uint, request CreateServiceAccountTokenRequest) (*ServiceAccountToken, error) {
+	buf, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPost, fmt.Sprintf("/api/serviceaccounts/%d/tokens", serviceAccountID), buf)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrServiceAccountNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var token ServiceAccountToken
+	if err := decodeJSON(resp.Body, &token); err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// ListServiceAccountTokens lists the tokens of the given service account.
+func (client *Client) ListServiceAccountTokens(ctx context.Context, serviceAccountID uint) ([]ServiceAccountToken, error) {
+	resp, err := client.get(ctx, fmt.Sprintf("/api/serviceaccounts/%d/tokens", serviceAccountID))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrServiceAccountNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var tokens []ServiceAccountToken
+	if err := decodeJSON(resp.Body, &tokens); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// RevokeServiceAccountToken deletes a token of the given service account.
+func (client *Client) RevokeServiceAccountToken(ctx context.Context, serviceAccountID uint, tokenID uint) error {
+	resp, err := client.delete(ctx, fmt.Sprintf("/api/serviceaccounts/%d/tokens/%d", serviceAccountID, tokenID))
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
+
+// MigrateAPIKeys converts every API key of the current organization into a
+// service account holding an equivalent token: existing keys keep working,
+// as service account tokens.
+func (client *Client) MigrateAPIKeys(ctx context.Context) (*APIKeysMigrationResult, error) {
+	resp, err := client.sendJSON(ctx, http.MethodPost, "/api/serviceaccounts/migrate", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var result APIKeysMigrationResult
+	if err := decodeJSON(resp.Body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// MigrateAPIKeyByName converts the given API key into a service account
+// holding an equivalent token.
+func (client *Client) MigrateAPIKeyByName(ctx context.Context, name string) error {
+	apiKeys, err := client.APIKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	apiKey, ok := apiKeys[name]
+	if !ok {
+		return ErrAPIKeyNotFound
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPost, fmt.Sprintf("/api/serviceaccounts/migrate/%d", apiKey.ID), nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrAPIKeyNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
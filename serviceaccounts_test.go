@@ -0,0 +1,172 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceAccountsCanBeCreated(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := map[string]interface{}{}
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+		req.Equal("ci", payload["name"])
+		req.Equal("Editor", payload["role"])
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintln(w, `{"id": 3, "name": "ci", "login": "sa-ci", "orgId": 1, "role": "Editor", "isDisabled": false}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	account, err := client.CreateServiceAccount(context.TODO(), CreateServiceAccountRequest{Name: "ci", Role: OrgRoleEditor})
+
+	req.NoError(err)
+	req.Equal(uint(3), account.ID)
+	req.Equal("sa-ci", account.Login)
+}
+
+func TestServiceAccountsAreListedAcrossPages(t *testing.T) {
+	req := require.New(t)
+
+	var pages []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+
+		accounts := []ServiceAccount{}
+		count := 100
+		if page == "2" {
+			count = 1
+		}
+		for i := 0; i < count; i++ {
+			accounts = append(accounts, ServiceAccount{ID: uint(len(pages)*1000 + i), Name: fmt.Sprintf("sa-%s-%d", page, i)})
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"totalCount":      101,
+			"serviceAccounts": accounts,
+		})
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	accounts, err := client.ListServiceAccounts(context.TODO())
+
+	req.NoError(err)
+	req.Len(accounts, 101)
+	req.Equal([]string{"1", "2"}, pages)
+}
+
+func TestAnExplicitErrorIsReturnedIfTheServiceAccountIsNotFound(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("ci", r.URL.Query().Get("query"))
+		_, _ = fmt.Fprintln(w, `{"totalCount": 1, "serviceAccounts": [{"id": 3, "name": "ci-bot"}]}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	account, err := client.GetServiceAccountByName(context.TODO(), "ci")
+
+	req.Nil(account)
+	req.Equal(ErrServiceAccountNotFound, err)
+	req.True(IsNotFound(err))
+}
+
+func TestServiceAccountTokensCanBeManaged(t *testing.T) {
+	req := require.New(t)
+
+	var calls []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+
+		switch r.Method {
+		case http.MethodPost:
+			payload := map[string]interface{}{}
+			req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+			req.Equal(float64(3600), payload["secondsToLive"])
+
+			_, _ = fmt.Fprintln(w, `{"id": 9, "name": "deploy", "key": "glsa_secret"}`)
+		case http.MethodGet:
+			_, _ = fmt.Fprintln(w, `[{"id": 9, "name": "deploy", "created": "2023-01-01T00:00:00Z", "expiration": "2023-01-01T01:00:00Z", "hasExpired": true}]`)
+		default:
+			_, _ = fmt.Fprintln(w, `{"message": "API key deleted"}`)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	token, err := client.CreateServiceAccountToken(context.TODO(), 3, CreateServiceAccountTokenRequest{Name: "deploy", SecondsToLive: 3600})
+	req.NoError(err)
+	req.Equal("glsa_secret", token.Key)
+
+	tokens, err := client.ListServiceAccountTokens(context.TODO(), 3)
+	req.NoError(err)
+	req.Len(tokens, 1)
+	req.True(tokens[0].HasExpired)
+	req.NotNil(tokens[0].Expiration)
+
+	req.NoError(client.RevokeServiceAccountToken(context.TODO(), 3, 9))
+	req.NoError(client.DeleteServiceAccount(context.TODO(), 3))
+
+	req.Equal([]string{
+		"POST /api/serviceaccounts/3/tokens",
+		"GET /api/serviceaccounts/3/tokens",
+		"DELETE /api/serviceaccounts/3/tokens/9",
+		"DELETE /api/serviceaccounts/3",
+	}, calls)
+}
+
+func TestAPIKeysCanBeMigrated(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodPost, r.Method)
+		req.Equal("/api/serviceaccounts/migrate", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `{"total": 3, "migrated": 2, "failed": 1, "failedApikeyIDs": [4], "failedDetails": ["key 4: conflict"]}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	result, err := client.MigrateAPIKeys(context.TODO())
+
+	req.NoError(err)
+	req.Equal(2, result.Migrated)
+	req.Equal([]uint{4}, result.FailedAPIKeyIDs)
+}
+
+func TestASingleAPIKeyCanBeMigrated(t *testing.T) {
+	req := require.New(t)
+
+	migrated := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = fmt.Fprintln(w, `[{"id": 2, "name": "foo"}]`)
+			return
+		}
+
+		req.Equal("/api/serviceaccounts/migrate/2", r.URL.Path)
+		migrated = true
+		_, _ = fmt.Fprintln(w, `{}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	req.NoError(client.MigrateAPIKeyByName(context.TODO(), "foo"))
+	req.True(migrated)
+
+	req.Equal(ErrAPIKeyNotFound, client.MigrateAPIKeyByName(context.TODO(), "unknown"))
+}
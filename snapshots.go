@@ -0,0 +1,260 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/K-Phoen/grabana/dashboard"
+	"github.com/K-Phoen/sdk"
+)
+
+// ErrSnapshotNotFound is returned when the given snapshot can not be found.
+var ErrSnapshotNotFound = errors.New("snapshot not found")
+
+// Snapshot represents a dashboard snapshot.
+// See https://grafana.com/docs/grafana/latest/dashboards/share-dashboards-panels/#publish-a-snapshot
+type Snapshot struct {
+	ID   uint   `json:"id"`
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	// DeleteKey and URL are only known when the snapshot is created.
+	DeleteKey string `json:"deleteKey,omitempty"`
+	URL       string `json:"url,omitempty"`
+	// External, Expires and Created are only known when listing snapshots.
+	External bool      `json:"external"`
+	Expires  time.Time `json:"expires"`
+	Created  time.Time `json:"created"`
+}
+
+// SnapshotOption represents an option that can be used to customize the
+// creation of a snapshot.
+type SnapshotOption func(opts *snapshotOptions)
+
+type snapshotOptions struct {
+	name    string
+	expires time.Duration
+	// panelData holds the data frozen in the snapshot, indexed by panel title.
+	panelData map[string]json.RawMessage
+}
+
+// SnapshotName sets the name of the snapshot. Defaults to the title of the
+// dashboard.
+func SnapshotName(name string) SnapshotOption {
+	return func(opts *snapshotOptions) {
+		opts.name = name
+	}
+}
+
+// SnapshotExpiry deletes the snapshot after the given duration. By default,
+// snapshots never expire.
+func SnapshotExpiry(expires time.Duration) SnapshotOption {
+	return func(opts *snapshotOptions) {
+		opts.expires = expires
+	}
+}
+
+// SnapshotPanelData embeds the data displayed by a panel, given its title.
+// data is the panel's "snapshotData" as Grafana expects it: usually a list
+// of data frames.
+func SnapshotPanelData(panelTitle string, data json.RawMessage) SnapshotOption {
+	return func(opts *snapshotOptions) {
+		if opts.panelData == nil {
+			opts.panelData = map[string]json.RawMessage{}
+		}
+
+		opts.panelData[panelTitle] = data
+	}
+}
+
+// CreateSnapshot creates a snapshot of the dashboard described by the given
+// builder. Snapshots are stored locally: they are never published externally.
+// Grafana does not query datasources when creating snapshots: they only
+// contain the layout of the dashboard, and the data given with
+// SnapshotPanelData.
+func (client *Client) CreateSnapshot(ctx context.Context, builder dashboard.Builder, options ...SnapshotOption) (*Snapshot, error) {
+	return client.createSnapshot(ctx, builder.Internal(), options...)
+}
+
+// CreateSnapshotByUID creates a snapshot of an existing dashboard, given
+// its UID. Snapshots are stored locally: they are never published externally.
+// Like with CreateSnapshot, they only contain the layout of the dashboard and
+// the data given with SnapshotPanelData.
+func (client *Client) CreateSnapshotByUID(ctx context.Context, dashboardUID string, options ...SnapshotOption) (*Snapshot, error) {
+	board, err := client.GetDashboardByUID(ctx, dashboardUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.createSnapshot(ctx, board, options...)
+}
+
+func (client *Client) createSnapshot(ctx context.Context, board *sdk.Board, options ...SnapshotOption) (*Snapshot, error) {
+	opts := snapshotOptions{name: board.Title}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	dashboardModel, err := snapshotDashboard(board, opts.panelData)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := json.Marshal(struct {
+		Dashboard map[string]interface{} `json:"dashboard"`
+		Name      string                 `json:"name"`
+		Expires   int64                  `json:"expires,omitempty"`
+		External  bool                   `json:"external"`
+	}{
+		Dashboard: dashboardModel,
+		Name:      opts.name,
+		Expires:   int64(opts.expires.Seconds()),
+		External:  false,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPost, "/api/snapshots", buf)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var snapshot Snapshot
+	if err := decodeJSON(resp.Body, &snapshot); err != nil {
+		return nil, err
+	}
+
+	snapshot.Name = opts.name
+
+	return &snapshot, nil
+}
+
+// snapshotDashboard renders the dashboard model sent to Grafana, with the
+// given data embedded in its panels.
+func snapshotDashboard(board *sdk.Board, panelData map[string]json.RawMessage) (map[string]interface{}, error) {
+	buf, err := json.Marshal(board)
+	if err != nil {
+		return nil, err
+	}
+
+	model := map[string]interface{}{}
+	if err := json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	embedded := map[string]bool{}
+
+	var embed func(panels interface{})
+	embed = func(panels interface{}) {
+		list, _ := panels.([]interface{})
+		for _, item := range list {
+			panel, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			title, _ := panel["title"].(string)
+			if data, ok := panelData[title]; ok {
+				panel["snapshotData"] = data
+				embedded[title] = true
+			}
+
+			// collapsed rows hold their own panels
+			embed(panel["panels"])
+		}
+	}
+
+	embed(model["panels"])
+	if rows, ok := model["rows"].([]interface{}); ok {
+		for _, row := range rows {
+			if row, ok := row.(map[string]interface{}); ok {
+				embed(row["panels"])
+			}
+		}
+	}
+
+	for title := range panelData {
+		if !embedded[title] {
+			return nil, fmt.Errorf("could not embed snapshot data: no panel titled '%s'", title)
+		}
+	}
+
+	return model, nil
+}
+
+// ListSnapshots returns the snapshots of the current organization.
+func (client *Client) ListSnapshots(ctx context.Context) ([]Snapshot, error) {
+	resp, err := client.get(ctx, "/api/dashboard/snapshots?limit=1000")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var snapshots []Snapshot
+	if err := decodeJSON(resp.Body, &snapshots); err != nil {
+		return nil, err
+	}
+
+	return snapshots, nil
+}
+
+// GetSnapshot fetches the dashboard frozen in a snapshot, given its key.
+func (client *Client) GetSnapshot(ctx context.Context, key string) (*sdk.Board, error) {
+	resp, err := client.get(ctx, "/api/snapshots/"+url.PathEscape(key))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrSnapshotNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	response := struct {
+		Board sdk.Board `json:"dashboard"`
+	}{}
+	if err := decodeJSON(resp.Body, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Board, nil
+}
+
+// DeleteSnapshot deletes a snapshot, given its key.
+func (client *Client) DeleteSnapshot(ctx context.Context, key string) error {
+	resp, err := client.delete(ctx, "/api/snapshots/"+url.PathEscape(key))
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrSnapshotNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
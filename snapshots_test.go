@@ -0,0 +1,170 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/K-Phoen/grabana/dashboard"
+	"github.com/K-Phoen/grabana/row"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotsCanBeCreatedFromBuilders(t *testing.T) {
+	req := require.New(t)
+
+	var payload map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodPost, r.Method)
+		req.Equal("/api/snapshots", r.URL.Path)
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+
+		_, _ = fmt.Fprintln(w, `{"deleteKey": "delete-key", "deleteUrl": "http://grafana/api/snapshots-delete/delete-key", "key": "snapshot-key", "url": "http://grafana/dashboard/snapshot/snapshot-key", "id": 1}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+	builder, err := dashboard.New("Incident dashboard")
+	req.NoError(err)
+
+	snapshot, err := client.CreateSnapshot(context.TODO(), builder, SnapshotName("INC-42"), SnapshotExpiry(time.Hour))
+
+	req.NoError(err)
+	req.Equal("INC-42", payload["name"])
+	req.Equal(float64(3600), payload["expires"])
+	req.Equal(false, payload["external"])
+	req.Equal("Incident dashboard", payload["dashboard"].(map[string]interface{})["title"])
+
+	req.Equal("snapshot-key", snapshot.Key)
+	req.Equal("delete-key", snapshot.DeleteKey)
+	req.Equal("INC-42", snapshot.Name)
+}
+
+func TestSnapshotsCanEmbedPanelData(t *testing.T) {
+	req := require.New(t)
+
+	var payload struct {
+		Dashboard struct {
+			Rows []struct {
+				Panels []map[string]interface{} `json:"panels"`
+			} `json:"rows"`
+		} `json:"dashboard"`
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+
+		_, _ = fmt.Fprintln(w, `{"key": "snapshot-key", "id": 1}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+	builder, err := dashboard.New("Incident dashboard", dashboard.Row("Errors", row.WithTimeSeries("Errors"), row.WithText("Notes")))
+	req.NoError(err)
+
+	data := json.RawMessage(`[{"fields": [{"name": "Value", "values": [1, 2]}]}]`)
+	_, err = client.CreateSnapshot(context.TODO(), builder, SnapshotPanelData("Errors", data))
+
+	req.NoError(err)
+	req.Len(payload.Dashboard.Rows, 1)
+
+	panels := payload.Dashboard.Rows[0].Panels
+	req.Len(panels, 2)
+	req.Equal("Errors", panels[0]["title"])
+	req.Len(panels[0]["snapshotData"], 1)
+	req.NotContains(panels[1], "snapshotData")
+}
+
+func TestSnapshotDataForUnknownPanelsIsRejected(t *testing.T) {
+	req := require.New(t)
+
+	client := NewClient(http.DefaultClient, "http://localhost")
+	builder, err := dashboard.New("Incident dashboard")
+	req.NoError(err)
+
+	_, err = client.CreateSnapshot(context.TODO(), builder, SnapshotPanelData("Errors", json.RawMessage(`[]`)))
+
+	req.Error(err)
+	req.Contains(err.Error(), "Errors")
+}
+
+func TestSnapshotsOfExistingDashboardsCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	var payload map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/dashboards/uid/dash-uid":
+			_, _ = fmt.Fprintln(w, `{"dashboard": {"uid": "dash-uid", "title": "Service"}}`)
+		case "/api/snapshots":
+			req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+			_, _ = fmt.Fprintln(w, `{"key": "snapshot-key", "id": 1}`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	snapshot, err := client.CreateSnapshotByUID(context.TODO(), "dash-uid")
+
+	req.NoError(err)
+	req.Equal("Service", payload["name"])
+	req.NotContains(payload, "expires")
+	req.Equal("Service", snapshot.Name)
+}
+
+func TestSnapshotsCanBeListed(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/dashboard/snapshots", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `[{"id": 1, "name": "INC-42", "key": "snapshot-key", "external": false, "expires": "2026-01-01T00:00:00Z", "created": "2025-01-01T00:00:00Z"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	snapshots, err := client.ListSnapshots(context.TODO())
+
+	req.NoError(err)
+	req.Len(snapshots, 1)
+	req.Equal("snapshot-key", snapshots[0].Key)
+	req.Equal(2026, snapshots[0].Expires.Year())
+}
+
+func TestSnapshotsCanBeFetched(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/snapshots/snapshot-key", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `{"meta": {"isSnapshot": true}, "dashboard": {"title": "Service"}}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	board, err := client.GetSnapshot(context.TODO(), "snapshot-key")
+
+	req.NoError(err)
+	req.Equal("Service", board.Title)
+}
+
+func TestDeletingAnUnknownSnapshotFailsCleanly(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodDelete, r.Method)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.DeleteSnapshot(context.TODO(), "unknown")
+
+	req.ErrorIs(err, ErrSnapshotNotFound)
+}
@@ -0,0 +1,335 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrTeamNotFound is returned when the given team can not be found.
+var ErrTeamNotFound = errors.New("team not found")
+
+// ErrUserNotFound is returned when the given user can not be found.
+var ErrUserNotFound = errors.New("user not found")
+
+// Team represents a team of users within an organization.
+// See https://grafana.com/docs/grafana/latest/administration/team-management/
+type Team struct {
+	ID          uint   `json:"id"`
+	OrgID       uint   `json:"orgId"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	MemberCount int    `json:"memberCount"`
+}
+
+// TeamMember represents a user, as a member of a team.
+type TeamMember struct {
+	TeamID uint   `json:"teamId"`
+	UserID uint   `json:"userId"`
+	Login  string `json:"login"`
+	Email  string `json:"email"`
+	Name   string `json:"name"`
+}
+
+// TeamPreferences holds the preferences applying to the members of a team.
+// Empty fields are left to their default value.
+type TeamPreferences struct {
+	Theme            string `json:"theme,omitempty"`
+	HomeDashboardUID string `json:"homeDashboardUID,omitempty"`
+	Timezone         string `json:"timezone,omitempty"`
+	WeekStart        string `json:"weekStart,omitempty"`
+}
+
+// CreateTeam creates a team in the current organization.
+func (client *Client) CreateTeam(ctx context.Context, name string, email string) (*Team, error) {
+	buf, err := json.Marshal(struct {
+		Name  string `json:"name"`
+		Email string `json:"email,omitempty"`
+	}{
+		Name:  name,
+		Email: email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPost, "/api/teams", buf)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var response struct {
+		TeamID uint `json:"teamId"`
+	}
+	if err := decodeJSON(resp.Body, &response); err != nil {
+		return nil, err
+	}
+
+	return &Team{ID: response.TeamID, Name: name, Email: email}, nil
+}
+
+// ListTeams returns the teams of the current organization.
+func (client *Client) ListTeams(ctx context.Context) ([]Team, error) {
+	return client.searchTeams(ctx, url.Values{})
+}
+
+// GetTeamByName finds a team, given its name.
+func (client *Client) GetTeamByName(ctx context.Context, name string) (*Team, error) {
+	teams, err := client.searchTeams(ctx, url.Values{"name": []string{name}})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range teams {
+		if strings.EqualFold(teams[i].Name, name) {
+			return &teams[i], nil
+		}
+	}
+
+	return nil, ErrTeamNotFound
+}
+
+// FindOrCreateTeam returns the team by its name or creates it if it doesn't exist.
+func (client *Client) FindOrCreateTeam(ctx context.Context, name string) (*Team, error) {
+	team, err := client.GetTeamByName(ctx, name)
+	if err != nil && err != ErrTeamNotFound {
+		return nil, fmt.Errorf("could not find or create team: %w", err)
+	}
+	if team == nil {
+		team, err = client.CreateTeam(ctx, name, "")
+		if err != nil {
+			return nil, fmt.Errorf("could not create team: %w", err)
+		}
+	}
+
+	return team, nil
+}
+
+func (client *Client) searchTeams(ctx context.Context, query url.Values) ([]Team, error) {
+	const perPage = 100
+
+	var teams []Team
+
+	query.Set("perpage", fmt.Sprintf("%d", perPage))
+
+	for page := 1; ; page++ {
+		query.Set("page", fmt.Sprintf("%d", page))
+
+		resp, err := client.get(ctx, "/api/teams/search?"+query.Encode())
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			err := client.httpError(resp)
+			_ = resp.Body.Close()
+
+			return nil, err
+		}
+
+		var response struct {
+			TotalCount int    `json:"totalCount"`
+			Teams      []Team `json:"teams"`
+		}
+		err = decodeJSON(resp.Body, &response)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		teams = append(teams, response.Teams...)
+
+		if len(response.Teams) < perPage || len(teams) >= response.TotalCount {
+			return teams, nil
+		}
+	}
+}
+
+// DeleteTeam deletes a team.
+func (client *Client) DeleteTeam(ctx context.Context, teamID uint) error {
+	resp, err := client.delete(ctx, fmt.Sprintf("/api/teams/%d", teamID))
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrTeamNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
+
+// ListTeamMembers returns the members of a team.
+func (client *Client) ListTeamMembers(ctx context.Context, teamID uint) ([]TeamMember, error) {
+	resp, err := client.get(ctx, fmt.Sprintf("/api/teams/%d/members", teamID))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrTeamNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var members []TeamMember
+	if err := decodeJSON(resp.Body, &members); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// AddTeamMember adds a user of the current organization to a team, given
+// their login or email.
+func (client *Client) AddTeamMember(ctx context.Context, teamID uint, loginOrEmail string) error {
+	userID, err := client.lookupOrgUser(ctx, loginOrEmail)
+	if err != nil {
+		return err
+	}
+
+	buf, err := json.Marshal(struct {
+		UserID uint `json:"userId"`
+	}{
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPost, fmt.Sprintf("/api/teams/%d/members", teamID), buf)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrTeamNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
+
+// RemoveTeamMember removes a user from a team, given their login or email.
+func (client *Client) RemoveTeamMember(ctx context.Context, teamID uint, loginOrEmail string) error {
+	userID, err := client.lookupOrgUser(ctx, loginOrEmail)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.delete(ctx, fmt.Sprintf("/api/teams/%d/members/%d", teamID, userID))
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrTeamNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
+
+// lookupOrgUser finds the ID of a user of the current organization, given
+// their login or email.
+// Note: /api/org/users/lookup is not used as its results do not include
+// the email of users.
+func (client *Client) lookupOrgUser(ctx context.Context, loginOrEmail string) (uint, error) {
+	resp, err := client.get(ctx, "/api/org/users?limit=100&query="+url.QueryEscape(loginOrEmail))
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, client.httpError(resp)
+	}
+
+	var users []OrgUser
+	if err := decodeJSON(resp.Body, &users); err != nil {
+		return 0, err
+	}
+
+	for _, user := range users {
+		if user.Login == loginOrEmail || strings.EqualFold(user.Email, loginOrEmail) {
+			return user.UserID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %s", ErrUserNotFound, loginOrEmail)
+}
+
+// GetTeamPreferences returns the preferences of a team.
+func (client *Client) GetTeamPreferences(ctx context.Context, teamID uint) (*TeamPreferences, error) {
+	resp, err := client.get(ctx, fmt.Sprintf("/api/teams/%d/preferences", teamID))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrTeamNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var preferences TeamPreferences
+	if err := decodeJSON(resp.Body, &preferences); err != nil {
+		return nil, err
+	}
+
+	return &preferences, nil
+}
+
+// SetTeamPreferences replaces the preferences of a team.
+func (client *Client) SetTeamPreferences(ctx context.Context, teamID uint, preferences TeamPreferences) error {
+	buf, err := json.Marshal(preferences)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPut, fmt.Sprintf("/api/teams/%d/preferences", teamID), buf)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrTeamNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
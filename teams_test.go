@@ -0,0 +1,170 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamsCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	var payload map[string]string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodPost, r.Method)
+		req.Equal("/api/teams", r.URL.Path)
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+
+		_, _ = fmt.Fprintln(w, `{"message": "Team created", "teamId": 2}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	team, err := client.CreateTeam(context.TODO(), "Platform", "platform@example.com")
+
+	req.NoError(err)
+	req.Equal(map[string]string{"name": "Platform", "email": "platform@example.com"}, payload)
+	req.Equal(uint(2), team.ID)
+	req.Equal("Platform", team.Name)
+}
+
+func TestATeamCanBeFoundByName(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/teams/search", r.URL.Path)
+		req.Equal("Platform", r.URL.Query().Get("name"))
+
+		_, _ = fmt.Fprintln(w, `{"totalCount": 1, "teams": [{"id": 2, "orgId": 1, "name": "Platform", "memberCount": 3}]}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	team, err := client.GetTeamByName(context.TODO(), "Platform")
+
+	req.NoError(err)
+	req.Equal(uint(2), team.ID)
+	req.Equal(3, team.MemberCount)
+}
+
+func TestAnExplicitErrorIsReturnedIfTheTeamIsNotFound(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintln(w, `{"totalCount": 0, "teams": []}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.GetTeamByName(context.TODO(), "Platform")
+
+	req.ErrorIs(err, ErrTeamNotFound)
+}
+
+func TestTeamMembersCanBeAddedByEmail(t *testing.T) {
+	req := require.New(t)
+
+	var payload map[string]uint
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/org/users":
+			req.Equal("jane@example.com", r.URL.Query().Get("query"))
+			_, _ = fmt.Fprintln(w, `[{"userId": 4, "login": "jane", "email": "Jane@example.com"}, {"userId": 5, "login": "janet", "email": "janet@example.com"}]`)
+		case "/api/teams/2/members":
+			req.Equal(http.MethodPost, r.Method)
+			req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+			_, _ = fmt.Fprintln(w, `{"message": "Member added to Team"}`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.AddTeamMember(context.TODO(), 2, "jane@example.com")
+
+	req.NoError(err)
+	req.Equal(map[string]uint{"userId": 4}, payload)
+}
+
+func TestAddingAnUnknownUserToATeamFails(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.AddTeamMember(context.TODO(), 2, "unknown")
+
+	req.ErrorIs(err, ErrUserNotFound)
+}
+
+func TestTeamMembersCanBeRemovedByLogin(t *testing.T) {
+	req := require.New(t)
+
+	deleted := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/org/users":
+			_, _ = fmt.Fprintln(w, `[{"userId": 4, "login": "jane", "email": "jane@example.com"}]`)
+		case "/api/teams/2/members/4":
+			req.Equal(http.MethodDelete, r.Method)
+			deleted = true
+			_, _ = fmt.Fprintln(w, `{"message": "Team Member removed"}`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.RemoveTeamMember(context.TODO(), 2, "jane")
+
+	req.NoError(err)
+	req.True(deleted)
+}
+
+func TestTeamPreferencesCanBeSet(t *testing.T) {
+	req := require.New(t)
+
+	var payload map[string]string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodPut, r.Method)
+		req.Equal("/api/teams/2/preferences", r.URL.Path)
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+
+		_, _ = fmt.Fprintln(w, `{"message": "Preferences updated"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.SetTeamPreferences(context.TODO(), 2, TeamPreferences{Theme: "dark", HomeDashboardUID: "home"})
+
+	req.NoError(err)
+	req.Equal(map[string]string{"theme": "dark", "homeDashboardUID": "home"}, payload)
+}
+
+func TestDeletingAnUnknownTeamFailsCleanly(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.DeleteTeam(context.TODO(), 42)
+
+	req.ErrorIs(err, ErrTeamNotFound)
+}
@@ -412,7 +412,7 @@ func Alert(name string, opts ...alert.Option) Option {
 		obj := alert.New(name, opts...)
 
 		for i, data := range obj.Builder.Data {
-			if data.DatasourceUid == "" {
+			if data.DatasourceUid == "" && timeseries.Builder.Datasource != nil {
 				data.DatasourceUid = timeseries.Builder.Datasource.LegacyName
 				obj.Builder.Data[i] = data
 			}
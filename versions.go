@@ -0,0 +1,149 @@
+package grabana
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/K-Phoen/sdk"
+)
+
+// ErrDashboardVersionNotFound is returned when the given dashboard version can not be found.
+var ErrDashboardVersionNotFound = errors.New("dashboard version not found")
+
+// DashboardVersion describes a version of a dashboard, as stored in its
+// history.
+type DashboardVersion struct {
+	ID            uint      `json:"id"`
+	DashboardID   uint      `json:"dashboardId"`
+	ParentVersion int       `json:"parentVersion"`
+	RestoredFrom  int       `json:"restoredFrom"`
+	Version       int       `json:"version"`
+	Created       time.Time `json:"created"`
+	CreatedBy     string    `json:"createdBy"`
+	Message       string    `json:"message"`
+}
+
+// ListDashboardVersions lists the versions of a dashboard, most recent first.
+func (client *Client) ListDashboardVersions(ctx context.Context, dashboardUID string) ([]DashboardVersion, error) {
+	resp, err := client.get(ctx, "/api/dashboards/uid/"+url.PathEscape(dashboardUID)+"/versions?limit=1000")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrDashboardNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var payload json.RawMessage
+	if err := decodeJSON(resp.Body, &payload); err != nil {
+		return nil, err
+	}
+
+	// Grafana 10 and older return a plain list, newer versions wrap it.
+	var versions []DashboardVersion
+	if bytes.HasPrefix(bytes.TrimSpace(payload), []byte("[")) {
+		if err := json.Unmarshal(payload, &versions); err != nil {
+			return nil, err
+		}
+
+		return versions, nil
+	}
+
+	wrapped := struct {
+		Versions []DashboardVersion `json:"versions"`
+	}{}
+	if err := json.Unmarshal(payload, &wrapped); err != nil {
+		return nil, err
+	}
+
+	return wrapped.Versions, nil
+}
+
+// GetDashboardVersion fetches the model of a dashboard, as it was in the
+// given version.
+func (client *Client) GetDashboardVersion(ctx context.Context, dashboardUID string, version int) (*sdk.Board, error) {
+	resp, err := client.get(ctx, fmt.Sprintf("/api/dashboards/uid/%s/versions/%d", url.PathEscape(dashboardUID), version))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrDashboardVersionNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	response := struct {
+		Data sdk.Board `json:"data"`
+	}{}
+	if err := decodeJSON(resp.Body, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// DiffDashboardVersions compares two versions of a dashboard. The resulting
+// diff describes the changes needed to go from the base version to the new one.
+func (client *Client) DiffDashboardVersions(ctx context.Context, dashboardUID string, baseVersion int, newVersion int) (*DashboardDiff, error) {
+	base, err := client.GetDashboardVersion(ctx, dashboardUID, baseVersion)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch version %d: %w", baseVersion, err)
+	}
+
+	target, err := client.GetDashboardVersion(ctx, dashboardUID, newVersion)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch version %d: %w", newVersion, err)
+	}
+
+	return diffBoards(target, base)
+}
+
+// RestoreDashboardVersion restores a previous version of a dashboard. The
+// restoration is itself recorded as a new version.
+// Note: alert rules provisioned alongside the dashboard are left untouched.
+func (client *Client) RestoreDashboardVersion(ctx context.Context, dashboardUID string, version int) (*Dashboard, error) {
+	buf, err := json.Marshal(struct {
+		Version int `json:"version"`
+	}{
+		Version: version,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPost, "/api/dashboards/uid/"+url.PathEscape(dashboardUID)+"/restore", buf)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrDashboardVersionNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var model Dashboard
+	if err := decodeJSON(resp.Body, &model); err != nil {
+		return nil, err
+	}
+
+	return &model, nil
+}
@@ -0,0 +1,158 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDashboardVersionsCanBeListed(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		response string
+	}{
+		{
+			desc:     "plain list",
+			response: `[{"id": 2, "dashboardId": 1, "parentVersion": 1, "version": 2, "createdBy": "admin", "message": "sha-2"}, {"id": 1, "dashboardId": 1, "version": 1, "createdBy": "admin"}]`,
+		},
+		{
+			desc:     "wrapped list",
+			response: `{"continueToken": "", "versions": [{"id": 2, "dashboardId": 1, "parentVersion": 1, "version": 2, "createdBy": "admin", "message": "sha-2"}, {"id": 1, "dashboardId": 1, "version": 1, "createdBy": "admin"}]}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+
+		t.Run(tc.desc, func(t *testing.T) {
+			req := require.New(t)
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				req.Equal("/api/dashboards/uid/dash-uid/versions", r.URL.Path)
+
+				_, _ = fmt.Fprintln(w, tc.response)
+			}))
+			defer ts.Close()
+
+			client := NewClient(http.DefaultClient, ts.URL)
+
+			versions, err := client.ListDashboardVersions(context.TODO(), "dash-uid")
+
+			req.NoError(err)
+			req.Len(versions, 2)
+			req.Equal(2, versions[0].Version)
+			req.Equal(1, versions[0].ParentVersion)
+			req.Equal("sha-2", versions[0].Message)
+			req.Equal(1, versions[1].Version)
+		})
+	}
+}
+
+func TestListingVersionsOfAnUnknownDashboardFailsCleanly(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.ListDashboardVersions(context.TODO(), "unknown")
+
+	req.ErrorIs(err, ErrDashboardNotFound)
+}
+
+func TestADashboardVersionCanBeFetched(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/dashboards/uid/dash-uid/versions/3", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `{"id": 3, "version": 3, "data": {"uid": "dash-uid", "title": "Old title"}}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	board, err := client.GetDashboardVersion(context.TODO(), "dash-uid", 3)
+
+	req.NoError(err)
+	req.Equal("Old title", board.Title)
+}
+
+func TestFetchingAnUnknownDashboardVersionFailsCleanly(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.GetDashboardVersion(context.TODO(), "dash-uid", 42)
+
+	req.ErrorIs(err, ErrDashboardVersionNotFound)
+	req.True(IsNotFound(err))
+}
+
+func TestDashboardVersionsCanBeDiffed(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/dashboards/uid/dash-uid/versions/1":
+			_, _ = fmt.Fprintln(w, `{"version": 1, "data": {"uid": "dash-uid", "title": "Title", "editable": false}}`)
+		case "/api/dashboards/uid/dash-uid/versions/2":
+			_, _ = fmt.Fprintln(w, `{"version": 2, "data": {"uid": "dash-uid", "title": "Title", "editable": true}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	diff, err := client.DiffDashboardVersions(context.TODO(), "dash-uid", 1, 2)
+
+	req.NoError(err)
+	req.True(diff.Exists)
+	req.True(diff.HasChanges())
+}
+
+func TestDiffingWithAnUnknownDashboardVersionFails(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.DiffDashboardVersions(context.TODO(), "dash-uid", 1, 2)
+
+	req.ErrorIs(err, ErrDashboardVersionNotFound)
+}
+
+func TestADashboardVersionCanBeRestored(t *testing.T) {
+	req := require.New(t)
+
+	var payload map[string]int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodPost, r.Method)
+		req.Equal("/api/dashboards/uid/dash-uid/restore", r.URL.Path)
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+
+		_, _ = fmt.Fprintln(w, `{"id": 1, "uid": "dash-uid", "url": "/d/dash-uid/title", "status": "success", "version": 4}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	dashboard, err := client.RestoreDashboardVersion(context.TODO(), "dash-uid", 2)
+
+	req.NoError(err)
+	req.Equal(map[string]int{"version": 2}, payload)
+	req.Equal("dash-uid", dashboard.UID)
+}